@@ -29,14 +29,25 @@ type Runner struct {
 func NewRunner(cfg config.Config, logger *zap.Logger, llmClient *llm.Client) *Runner {
 	logger = logger.Named("cli")
 	opts := Options{
-		EvotorToken:   cfg.EvotorToken,
-		EvotorStoreID: cfg.EvotorStoreID,
-		LLMBaseURL:    cfg.LLMBaseURL,
-		LLMAPIKey:     cfg.LLMAPIKey,
-		LLMModel:      cfg.LLMModel,
-		Timeout:       cfg.Timeout,
-		LogFile:       cfg.LogFile,
-		Debug:         cfg.Debug,
+		EvotorToken:      cfg.EvotorToken,
+		EvotorStoreID:    cfg.EvotorStoreID,
+		EvotorBaseURL:    cfg.EvotorBaseURL,
+		StoreTimezone:    cfg.StoreTimezone,
+		LLMBaseURL:       cfg.LLMBaseURL,
+		LLMAPIKey:        cfg.LLMAPIKey,
+		LLMModel:         cfg.LLMModel,
+		Timeout:          cfg.Timeout,
+		CacheDir:         cfg.CacheDir,
+		CatalogTTL:       cfg.CatalogTTL,
+		EvotorRPS:        cfg.EvotorRPS,
+		EvotorBurst:      cfg.EvotorBurst,
+		RetryMaxAttempts: cfg.RetryMaxAttempts,
+		RetryBaseDelay:   cfg.RetryBaseDelay,
+		RetryMaxDelay:    cfg.RetryMaxDelay,
+		RetryAfterMax:    cfg.RetryAfterMax,
+		FetchConcurrency: cfg.FetchConcurrency,
+		LogFile:          cfg.LogFile,
+		Debug:            cfg.Debug,
 	}
 
 	return &Runner{
@@ -51,22 +62,38 @@ func (r *Runner) Execute() error {
 }
 
 func runCLI(opts *Options, logger *zap.Logger) error {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "fake-evotor":
+			return runFakeEvotor(os.Args[2:], logger)
+		case "sync":
+			return runSync(opts, os.Args[2:], logger)
+		}
+	}
+
 	var timeoutSeconds int
 
 	fs := flag.NewFlagSet("evotor-ai", flag.ContinueOnError)
 	fs.SetOutput(os.Stderr)
 	fs.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s [flags] [query]\n", fs.Name())
+		fmt.Fprintf(os.Stderr, "       %s sync products [flags]\n", fs.Name())
+		fmt.Fprintf(os.Stderr, "       %s fake-evotor [flags]\n", fs.Name())
 		fs.PrintDefaults()
 	}
 
 	fs.StringVar(&opts.EvotorToken, "token", opts.EvotorToken, "Evotor API token (EVOTOR_TOKEN)")
 	fs.StringVar(&opts.EvotorStoreID, "store-id", opts.EvotorStoreID, "Evotor store ID (EVOTOR_STORE_ID)")
+	fs.StringVar(&opts.EvotorBaseURL, "evotor-base-url", opts.EvotorBaseURL, "Evotor API base URL (EVOTOR_BASE_URL)")
+	fs.StringVar(&opts.StoreTimezone, "store-timezone", opts.StoreTimezone, "Store timezone, e.g. Asia/Vladivostok or <store_id>=<zone>,... (STORE_TIMEZONE)")
 	fs.StringVar(&opts.From, "from", "", "Start date (YYYY-MM-DD)")
 	fs.StringVar(&opts.To, "to", "", "End date (YYYY-MM-DD)")
 	fs.BoolVar(&opts.JSON, "json", false, "Output JSON format")
+	fs.BoolVar(&opts.Raw, "raw", false, "Output JSON with fetched documents exactly as Evotor returned them")
 	fs.BoolVar(&opts.Debug, "debug", opts.Debug, "Enable debug logging")
 	fs.StringVar(&opts.LogFile, "log-file", opts.LogFile, "Log file path")
+	fs.StringVar(&opts.CacheDir, "cache-dir", opts.CacheDir, "Local cache directory, empty disables disk cache (CACHE_DIR)")
+	fs.IntVar(&opts.FetchConcurrency, "fetch-concurrency", opts.FetchConcurrency, "Parallel document requests (FETCH_CONCURRENCY)")
 	fs.IntVar(&timeoutSeconds, "timeout", int(opts.Timeout.Seconds()), "Timeout in seconds")
 	fs.StringVar(&opts.LLMBaseURL, "llm-base-url", opts.LLMBaseURL, "LLM base URL (LLM_BASE_URL)")
 	fs.StringVar(&opts.LLMAPIKey, "llm-api-key", opts.LLMAPIKey, "LLM API key (LLM_API_KEY)")
@@ -98,9 +125,18 @@ func runCLI(opts *Options, logger *zap.Logger) error {
 	}
 	evotorClient := newEvotorClientFromOptions(opts, logger)
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := signalContext()
 	defer cancel()
 
+	if opts.Query == "" {
+		return runREPL(ctx, opts, logger, updatedLLMClient, evotorClient)
+	}
+	return runOneShot(ctx, opts, logger, updatedLLMClient, evotorClient, opts.Query)
+}
+
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
@@ -108,10 +144,7 @@ func runCLI(opts *Options, logger *zap.Logger) error {
 		cancel()
 	}()
 
-	if opts.Query == "" {
-		return runREPL(ctx, opts, logger, updatedLLMClient, evotorClient)
-	}
-	return runOneShot(ctx, opts, logger, updatedLLMClient, evotorClient, opts.Query)
+	return ctx, cancel
 }
 
 func newLLMClientFromOptions(opts *Options, logger *zap.Logger) (*llm.Client, error) {
@@ -126,9 +159,20 @@ func newLLMClientFromOptions(opts *Options, logger *zap.Logger) (*llm.Client, er
 
 func newEvotorClientFromOptions(opts *Options, logger *zap.Logger) *evotor.Client {
 	cfg := config.Config{
-		EvotorToken:   opts.EvotorToken,
-		EvotorStoreID: opts.EvotorStoreID,
-		Timeout:       opts.Timeout,
+		EvotorToken:      opts.EvotorToken,
+		EvotorStoreID:    opts.EvotorStoreID,
+		EvotorBaseURL:    opts.EvotorBaseURL,
+		StoreTimezone:    opts.StoreTimezone,
+		Timeout:          opts.Timeout,
+		CacheDir:         opts.CacheDir,
+		CatalogTTL:       opts.CatalogTTL,
+		EvotorRPS:        opts.EvotorRPS,
+		EvotorBurst:      opts.EvotorBurst,
+		RetryMaxAttempts: opts.RetryMaxAttempts,
+		RetryBaseDelay:   opts.RetryBaseDelay,
+		RetryMaxDelay:    opts.RetryMaxDelay,
+		RetryAfterMax:    opts.RetryAfterMax,
+		FetchConcurrency: opts.FetchConcurrency,
 	}
 	return evotor.NewClient(cfg, logger)
 }
@@ -140,7 +184,7 @@ func runOneShot(ctx context.Context, opts *Options, logger *zap.Logger, llmClien
 func runREPL(ctx context.Context, opts *Options, logger *zap.Logger, llmClient *llm.Client, evotorClient *evotor.Client) error {
 	reader := bufio.NewScanner(os.Stdin)
 	history := NewSessionHistory(defaultHistoryMaxMessages, defaultHistoryMaxTokens, logger)
-	history.Append(openrouter.SystemMessage(llm.SystemPromptWithContext(true)))
+	history.Append(openrouter.SystemMessage(llm.SystemPromptWithContext(true, storeNow(ctx, logger, evotorClient, opts))))
 	fmt.Fprintln(os.Stdout, "Evotor AI CLI (type 'exit' to quit)")
 
 	for {
@@ -155,7 +199,7 @@ func runREPL(ctx context.Context, opts *Options, logger *zap.Logger, llmClient *
 			continue
 		case "/clear":
 			history.Clear()
-			history.Append(openrouter.SystemMessage(llm.SystemPromptWithContext(true)))
+			history.Append(openrouter.SystemMessage(llm.SystemPromptWithContext(true, storeNow(ctx, logger, evotorClient, opts))))
 			fmt.Fprintln(os.Stdout, "История очищена.")
 			continue
 		case "/history":
@@ -218,6 +262,7 @@ func handleQuery(ctx context.Context, opts *Options, logger *zap.Logger, llmClie
 		zap.String("from", opts.From),
 		zap.String("to", opts.To),
 		zap.Bool("json", opts.JSON),
+		zap.Bool("raw", opts.Raw),
 	)
 
 	if strings.TrimSpace(opts.EvotorToken) == "" {
@@ -238,16 +283,19 @@ type jsonResponse struct {
 	AnswerText     string           `json:"answer_text"`
 	Results        any              `json:"results,omitempty"`
 	ToolCalls      []toolCallRecord `json:"tool_calls,omitempty"`
+	// RawDocuments are documents fetched during the query, as Evotor sent
+	// them; filled with --raw only.
+	RawDocuments []json.RawMessage `json:"raw_documents,omitempty"`
 }
 
 func writeResponse(opts *Options, resp response) error {
-	if opts.JSON {
-		return writeJSONResponse(resp)
+	if opts.JSON || opts.Raw {
+		return writeJSONResponse(resp, opts.Raw)
 	}
 	return writeHumanResponse(resp)
 }
 
-func writeJSONResponse(resp response) error {
+func writeJSONResponse(resp response, raw bool) error {
 	payload := jsonResponse{
 		Query:          resp.Query,
 		AppliedFilters: resp.AppliedFilters,
@@ -255,6 +303,13 @@ func writeJSONResponse(resp response) error {
 		Results:        resp.Results,
 		ToolCalls:      resp.ToolCalls,
 	}
+	if raw {
+		for _, call := range resp.ToolCalls {
+			if len(call.raw) > 0 {
+				payload.RawDocuments = append(payload.RawDocuments, call.raw)
+			}
+		}
+	}
 
 	enc := json.NewEncoder(os.Stdout)
 	return enc.Encode(payload)
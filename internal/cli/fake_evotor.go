@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"simple_answer_llm/internal/evotor/evotortest"
+
+	"go.uber.org/zap"
+)
+
+const defaultFakeEvotorAddr = "127.0.0.1:8085"
+
+func runFakeEvotor(args []string, logger *zap.Logger) error {
+	var (
+		addr        string
+		fixturesDir string
+		opts        evotortest.Options
+		forbidden   string
+		retryAfter  int
+	)
+
+	fs := flag.NewFlagSet("evotor-ai fake-evotor", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags]\n", fs.Name())
+		fs.PrintDefaults()
+	}
+
+	fs.StringVar(&addr, "addr", defaultFakeEvotorAddr, "Listen address")
+	fs.StringVar(&fixturesDir, "fixtures", "", "Fixtures directory (default: built-in demo data)")
+	fs.StringVar(&opts.Token, "token", "", "Expected bearer token (empty accepts any)")
+	fs.IntVar(&opts.PageSize, "page-size", 0, "Items per page")
+	fs.StringVar(&forbidden, "forbidden-stores", "", "Comma-separated store IDs answered with 403")
+	fs.IntVar(&opts.RateLimitEvery, "rate-limit-every", 0, "Answer every N-th request with 429")
+	fs.IntVar(&retryAfter, "retry-after", 1, "Retry-After for injected 429 responses, in seconds")
+
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	for _, storeID := range strings.Split(forbidden, ",") {
+		if storeID = strings.TrimSpace(storeID); storeID != "" {
+			opts.ForbiddenStores = append(opts.ForbiddenStores, storeID)
+		}
+	}
+	opts.RetryAfter = time.Duration(retryAfter) * time.Second
+
+	var (
+		fixtures *evotortest.Fixtures
+		err      error
+	)
+	if fixturesDir != "" {
+		fixtures, err = evotortest.LoadFixturesDir(fixturesDir)
+	} else {
+		fixtures, err = evotortest.DefaultFixtures()
+	}
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", addr, err)
+	}
+
+	server := &http.Server{
+		Handler:           evotortest.NewServer(fixtures, opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	baseURL := "http://" + listener.Addr().String()
+	logger.Info("fake evotor started", zap.String("base_url", baseURL), zap.String("fixtures", fixturesDir))
+	fmt.Fprintf(os.Stdout, "Fake Evotor API: %s (EVOTOR_BASE_URL=%s)\n", baseURL, baseURL)
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("fake evotor: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,29 +11,48 @@ import (
 
 	"simple_answer_llm/internal/evotor"
 	"simple_answer_llm/internal/llm"
+	"simple_answer_llm/internal/match"
 
 	openrouter "github.com/revrost/go-openrouter"
 	"go.uber.org/zap"
 )
 
-const maxToolRounds = 4
+const (
+	maxToolRounds       = 4
+	maxTopProductsLimit = 50
+	maxStockLimit       = 50
+	// localTimeLayout is RFC3339 without the offset, also accepted for from/to.
+	localTimeLayout = "2006-01-02T15:04:05"
+)
 
 func runLLMAgent(ctx context.Context, opts *Options, logger *zap.Logger, llmClient *llm.Client, evotorClient *evotor.Client, query string, interactive bool, history *SessionHistory) (response, error) {
 	if llmClient == nil || !llmClient.Enabled() {
 		return response{}, llm.ErrNotConfigured
 	}
 
+	var filters appliedFilters
+	userMessage := query
+	period, ok, err := flagPeriod(ctx, evotorClient, opts)
+	if err != nil {
+		return response{}, err
+	}
+	if ok {
+		filters.DateFrom = period.From.Format(time.RFC3339)
+		filters.DateTo = period.To.Format(time.RFC3339)
+		userMessage += fmt.Sprintf("\n\nПериод задан флагами --from/--to: from=%s, to=%s. Используй его в инструментах.", filters.DateFrom, filters.DateTo)
+	}
+
 	var messages []openrouter.ChatCompletionMessage
 	if history != nil {
 		if len(history.GetMessages()) == 0 {
-			history.Append(openrouter.SystemMessage(llm.SystemPromptWithContext(interactive)))
+			history.Append(openrouter.SystemMessage(llm.SystemPromptWithContext(interactive, storeNow(ctx, logger, evotorClient, opts))))
 		}
-		history.Append(openrouter.UserMessage(query))
+		history.Append(openrouter.UserMessage(userMessage))
 		messages = history.GetMessages()
 	} else {
 		messages = []openrouter.ChatCompletionMessage{
-			openrouter.SystemMessage(llm.SystemPromptWithContext(interactive)),
-			openrouter.UserMessage(query),
+			openrouter.SystemMessage(llm.SystemPromptWithContext(interactive, storeNow(ctx, logger, evotorClient, opts))),
+			openrouter.UserMessage(userMessage),
 		}
 	}
 
@@ -62,9 +82,10 @@ func runLLMAgent(ctx context.Context, opts *Options, logger *zap.Logger, llmClie
 				history.Append(msg)
 			}
 			return response{
-				Query:      query,
-				AnswerText: strings.TrimSpace(msg.Content.Text),
-				ToolCalls:  toolCalls,
+				Query:          query,
+				AnswerText:     strings.TrimSpace(msg.Content.Text),
+				AppliedFilters: filters,
+				ToolCalls:      toolCalls,
 			}, nil
 		}
 
@@ -84,18 +105,20 @@ func runLLMAgent(ctx context.Context, opts *Options, logger *zap.Logger, llmClie
 		}
 		if err != nil {
 			return response{
-				Query:      query,
-				AnswerText: friendlyEvotorError(err),
-				ToolCalls:  toolCalls,
+				Query:          query,
+				AnswerText:     friendlyEvotorError(err),
+				AppliedFilters: filters,
+				ToolCalls:      toolCalls,
 			}, nil
 		}
 	}
 
 	return response{
-		Query:      query,
-		AnswerText: "Не удалось завершить запрос: превышен лимит шагов.",
-		ToolCalls:  toolCalls,
-		NextStep:   "Уточните запрос или сузьте период/магазин.",
+		Query:          query,
+		AnswerText:     "Не удалось завершить запрос: превышен лимит шагов.",
+		AppliedFilters: filters,
+		ToolCalls:      toolCalls,
+		NextStep:       "Уточните запрос или сузьте период/магазин.",
 	}, nil
 }
 
@@ -150,22 +173,150 @@ func executeToolCalls(ctx context.Context, logger *zap.Logger, evotorClient *evo
 func dispatchToolCall(ctx context.Context, logger *zap.Logger, evotorClient *evotor.Client, opts *Options, name string, args map[string]any) (any, toolCallRecord, error) {
 	switch name {
 	case "GetSalesMetrics":
-		from, err := getTimeArg(args, "from")
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
 		if err != nil {
-			return nil, toolCallRecord{Name: name, Args: args, OK: false, Err: err.Error()}, err
+			return failedCall(name, args, err)
 		}
-		to, err := getTimeArg(args, "to")
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		if getBoolArg(args, "net") {
+			return trackCall(logger, name, args, func() (evotor.NetSalesMetrics, error) {
+				return evotorClient.GetNetSalesMetrics(ctx, from, to, optionalString(storeID))
+			})
+		}
+		documentType, err := getDocumentTypeArg(args, "document_type")
 		if err != nil {
-			return nil, toolCallRecord{Name: name, Args: args, OK: false, Err: err.Error()}, err
+			return failedCall(name, args, err)
+		}
+		if getBoolArg(args, "by_payment") {
+			return trackCall(logger, name, args, func() (evotor.PaymentMetrics, error) {
+				return evotorClient.GetPaymentMetrics(ctx, from, to, optionalString(storeID), documentType)
+			})
 		}
-		storeID := getStoreIDArg(args, opts.EvotorStoreID)
-		documentType, _ := getStringArg(args, "document_type")
 		return trackCall(logger, name, args, func() (evotor.SalesMetrics, error) {
-			var docType *string
-			if documentType != "" {
-				docType = &documentType
-			}
-			return evotorClient.GetSalesMetrics(ctx, from, to, optionalString(storeID), docType)
+			return evotorClient.GetSalesMetrics(ctx, from, to, optionalString(storeID), documentType)
+		})
+	case "GetSalesMetricsByStores":
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		storeIDs := getStringSliceArg(args, "store_ids")
+		documentType, err := getDocumentTypeArg(args, "document_type")
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		return trackCall(logger, name, args, func() (evotor.StoresSalesMetrics, error) {
+			return evotorClient.GetSalesMetricsByStores(ctx, from, to, storeIDs, documentType)
+		})
+	case "CompareSalesMetrics":
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		baseFrom, baseTo, baseline, err := getComparePeriodArgs(args, from, to)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		documentType, err := getDocumentTypeArg(args, "document_type")
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		return trackCall(logger, name, args, func() (evotor.SalesComparison, error) {
+			return evotorClient.CompareSalesMetrics(ctx, from, to, baseFrom, baseTo, optionalString(storeID), documentType, baseline)
+		})
+	case "GetSalesBreakdown":
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		bucketArg, _ := getStringArg(args, "bucket")
+		bucket, err := evotor.ParseBucket(bucketArg)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		loc, err := getLocationArg(args, "timezone")
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		documentType, err := getDocumentTypeArg(args, "document_type")
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		category, _ := getStringArg(args, "category")
+		return trackCall(logger, name, args, func() (evotor.SalesBreakdown, error) {
+			return evotorClient.GetSalesBreakdown(ctx, from, to, optionalString(storeID), evotor.BreakdownOptions{
+				Bucket:       bucket,
+				DocumentType: documentType,
+				Location:     loc,
+				Category:     category,
+			})
+		})
+	case "GetTopProducts":
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		by, _ := getStringArg(args, "by")
+		limit := min(getIntArg(args, "limit", defaultOutputLimit), maxTopProductsLimit)
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		documentType, err := getDocumentTypeArg(args, "document_type")
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		category, _ := getStringArg(args, "category")
+		groupBy, _ := getStringArg(args, "group_by")
+		return trackCall(logger, name, args, func() (evotor.TopProducts, error) {
+			return evotorClient.GetTopProducts(ctx, from, to, optionalString(storeID), evotor.TopProductsOptions{
+				By:           by,
+				Limit:        limit,
+				DocumentType: documentType,
+				Category:     category,
+				GroupBy:      groupBy,
+			})
+		})
+	case "GetDiscountReport":
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		limit := min(getIntArg(args, "limit", defaultOutputLimit), maxTopProductsLimit)
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		return trackCall(logger, name, args, func() (evotor.DiscountReport, error) {
+			return evotorClient.GetDiscountReport(ctx, from, to, optionalString(storeID), limit)
+		})
+	case "GetSalesByDevice":
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		documentType, err := getDocumentTypeArg(args, "document_type")
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		return trackCall(logger, name, args, func() (evotor.DeviceSales, error) {
+			return evotorClient.GetSalesByDevice(ctx, from, to, optionalString(storeID), documentType)
+		})
+	case "ListDevices":
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		return trackCall(logger, name, args, func() ([]evotor.Device, error) {
+			return evotorClient.ListDevices(ctx, optionalString(storeID))
+		})
+	case "GetSalesByEmployee":
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
+		if err != nil {
+			return failedCall(name, args, err)
+		}
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		return trackCall(logger, name, args, func() (evotor.EmployeeSales, error) {
+			return evotorClient.GetSalesByEmployee(ctx, from, to, optionalString(storeID))
+		})
+	case "ListEmployees":
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		return trackCall(logger, name, args, func() ([]evotor.Employee, error) {
+			return evotorClient.ListEmployees(ctx, optionalString(storeID))
 		})
 	case "ListStores":
 		return trackCall(logger, name, args, func() ([]evotor.Store, error) {
@@ -175,45 +326,86 @@ func dispatchToolCall(ctx context.Context, logger *zap.Logger, evotorClient *evo
 		query, _ := getStringArg(args, "query")
 		limit := getIntArg(args, "limit", defaultOutputLimit)
 		storeID := getStoreIDArg(args, opts.EvotorStoreID)
-		return trackCall(logger, name, args, func() ([]evotor.Item, error) {
-			return evotorClient.SearchItems(ctx, query, limit, optionalString(storeID))
+		category, _ := getStringArg(args, "category")
+		return trackCall(logger, name, args, func() ([]evotor.ItemMatch, error) {
+			return evotorClient.SearchItems(ctx, query, limit, optionalString(storeID), category)
+		})
+	case "GetStock":
+		query, _ := getStringArg(args, "query")
+		category, _ := getStringArg(args, "category")
+		status, _ := getStringArg(args, "status")
+		limit := min(getIntArg(args, "limit", defaultOutputLimit), maxStockLimit)
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		return trackCall(logger, name, args, func() (evotor.StockReport, error) {
+			return evotorClient.GetStock(ctx, optionalString(storeID), evotor.StockOptions{
+				Query:    query,
+				Category: category,
+				Status:   status,
+				Limit:    limit,
+			})
+		})
+	case "LookupItems":
+		code, _ := getStringArg(args, "code")
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		return trackCall(logger, name, args, func() ([]evotor.ItemMatch, error) {
+			return evotorClient.LookupItems(ctx, code, optionalString(storeID))
+		})
+	case "GetCategoryTree":
+		storeID := getStoreIDArg(args, opts.EvotorStoreID)
+		return trackCall(logger, name, args, func() ([]evotor.CategoryNode, error) {
+			return evotorClient.GetCategoryTree(ctx, optionalString(storeID))
 		})
 	case "SearchDocuments":
-		from, err := getTimeArg(args, "from")
-		if err != nil {
-			return nil, toolCallRecord{Name: name, Args: args, OK: false, Err: err.Error()}, err
-		}
-		to, err := getTimeArg(args, "to")
+		from, to, err := getPeriodArgs(ctx, evotorClient, opts, args)
 		if err != nil {
-			return nil, toolCallRecord{Name: name, Args: args, OK: false, Err: err.Error()}, err
+			return failedCall(name, args, err)
 		}
 		limit := getIntArg(args, "limit", defaultDocLimit)
-		offset := getIntArg(args, "offset", 0)
+		cursor, _ := getStringArg(args, "cursor")
 		storeID := getStoreIDArg(args, opts.EvotorStoreID)
 		itemQuery, _ := getStringArg(args, "item_query")
 		if strings.TrimSpace(itemQuery) == "" {
-			return trackCall(logger, name, args, func() ([]evotor.DocumentShort, error) {
-				return evotorClient.SearchDocuments(ctx, from, to, optionalString(storeID), limit, offset)
+			return trackCall(logger, name, args, func() (evotor.DocumentPage, error) {
+				return evotorClient.SearchDocuments(ctx, from, to, optionalString(storeID), limit, cursor)
 			})
 		}
 
-		documents, record, err := trackCall(logger, name, args, func() ([]evotor.DocumentShort, error) {
-			return evotorClient.SearchDocuments(ctx, from, to, optionalString(storeID), limit, offset)
+		page, record, err := trackCall(logger, name, args, func() (evotor.DocumentPage, error) {
+			return evotorClient.SearchDocuments(ctx, from, to, optionalString(storeID), limit, cursor)
 		})
 		if err != nil {
 			return nil, record, err
 		}
-		filtered, filterErr := filterDocumentsByItem(ctx, logger, evotorClient, storeID, documents, itemQuery)
-		return filtered, record, filterErr
+		filtered, filterErr := filterDocumentsByItem(ctx, logger, evotorClient, storeID, page.Documents, itemQuery)
+		if filterErr != nil {
+			return nil, record, filterErr
+		}
+		page.Documents = filtered
+		return page, record, nil
 	case "GetDocument":
 		docID, _ := getStringArg(args, "doc_id")
 		storeID := getStoreIDArg(args, opts.EvotorStoreID)
-		return trackCall(logger, name, args, func() (evotor.DocumentFull, error) {
+		doc, record, err := trackCall(logger, name, args, func() (evotor.DocumentFull, error) {
 			return evotorClient.GetDocument(ctx, docID, optionalString(storeID))
 		})
+		if err != nil {
+			return nil, record, err
+		}
+		record.raw = doc.Raw
+		if paths := getStringSliceArg(args, "fields"); len(paths) > 0 {
+			fields, fieldsErr := doc.Fields(paths)
+			if fieldsErr != nil {
+				return nil, record, fieldsErr
+			}
+			return fields, record, nil
+		}
+		if getBoolArg(args, "raw") && len(doc.Raw) > 0 {
+			return doc.Raw, record, nil
+		}
+		return doc, record, nil
 	default:
 		err := fmt.Errorf("unknown tool: %s", name)
-		return nil, toolCallRecord{Name: name, Args: args, OK: false, Err: err.Error()}, err
+		return failedCall(name, args, err)
 	}
 }
 
@@ -230,6 +422,49 @@ func getStringArg(args map[string]any, key string) (string, bool) {
 	}
 }
 
+func getStringSliceArg(args map[string]any, key string) []string {
+	value, ok := args[key]
+	if !ok {
+		return nil
+	}
+	switch v := value.(type) {
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if text := strings.TrimSpace(fmt.Sprintf("%v", item)); text != "" {
+				values = append(values, text)
+			}
+		}
+		return values
+	case string:
+		var values []string
+		for _, item := range strings.Split(v, ",") {
+			if text := strings.TrimSpace(item); text != "" {
+				values = append(values, text)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func getBoolArg(args map[string]any, key string) bool {
+	value, ok := args[key]
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		parsed, err := strconv.ParseBool(strings.TrimSpace(v))
+		return err == nil && parsed
+	default:
+		return false
+	}
+}
+
 func getIntArg(args map[string]any, key string, fallback int) int {
 	value, ok := args[key]
 	if !ok {
@@ -252,16 +487,115 @@ func getIntArg(args map[string]any, key string, fallback int) int {
 	return fallback
 }
 
-func getTimeArg(args map[string]any, key string) (time.Time, error) {
+// getPeriodArgs reads from/to as wall-clock times of the store the call is
+// about, whatever UTC offset the model wrote them with.
+func getPeriodArgs(ctx context.Context, evotorClient *evotor.Client, opts *Options, args map[string]any) (time.Time, time.Time, error) {
+	loc, err := toolLocation(ctx, evotorClient, getStoreIDArg(args, opts.EvotorStoreID))
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	from, err := getTimeArg(args, "from", loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	to, err := getTimeArg(args, "to", loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return from, to, nil
+}
+
+// toolLocation is the timezone of storeID, or the default one when no store
+// is selected (e.g. for a whole chain).
+func toolLocation(ctx context.Context, evotorClient *evotor.Client, storeID string) (*time.Location, error) {
+	loc, err := evotorClient.StoreLocation(ctx, optionalString(storeID))
+	if errors.Is(err, evotor.ErrMissingStoreID) {
+		return evotorClient.DefaultLocation(), nil
+	}
+	return loc, err
+}
+
+// storeNow is the current time in the default store's timezone, falling back
+// to the configured default when it cannot be resolved.
+func storeNow(ctx context.Context, logger *zap.Logger, evotorClient *evotor.Client, opts *Options) time.Time {
+	loc, err := toolLocation(ctx, evotorClient, opts.EvotorStoreID)
+	if err != nil {
+		logger.Warn("store timezone unavailable", zap.Error(err))
+		loc = evotorClient.DefaultLocation()
+	}
+	return time.Now().In(loc)
+}
+
+// flagPeriod resolves --from/--to on the calendar of the default store; ok
+// is false when neither flag is set.
+func flagPeriod(ctx context.Context, evotorClient *evotor.Client, opts *Options) (periodRange, bool, error) {
+	if opts.From == "" && opts.To == "" {
+		return periodRange{}, false, nil
+	}
+	loc, err := toolLocation(ctx, evotorClient, opts.EvotorStoreID)
+	if err != nil {
+		return periodRange{}, false, err
+	}
+	period, err := parsePeriodFromFlags(opts, loc, time.Now())
+	if err != nil {
+		return periodRange{}, false, err
+	}
+	return period, true, nil
+}
+
+func getTimeArg(args map[string]any, key string, loc *time.Location) (time.Time, error) {
 	value, ok := getStringArg(args, key)
 	if !ok || value == "" {
 		return time.Time{}, fmt.Errorf("missing %s", key)
 	}
 	parsed, err := time.Parse(time.RFC3339, value)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid %s: %w", key, err)
+		var localErr error
+		if parsed, localErr = time.Parse(localTimeLayout, value); localErr != nil {
+			return time.Time{}, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return evotor.InLocation(parsed, loc), nil
+}
+
+// getComparePeriodArgs uses explicit compare_from/compare_to when given and
+// derives the baseline period otherwise. It also returns the baseline name,
+// empty for explicit dates. Explicit dates are read in the location of from.
+func getComparePeriodArgs(args map[string]any, from, to time.Time) (time.Time, time.Time, string, error) {
+	if value, _ := getStringArg(args, "compare_from"); value == "" {
+		baseline, _ := getStringArg(args, "baseline")
+		baseFrom, baseTo, err := evotor.BaselinePeriod(from, to, baseline)
+		if baseline == "" {
+			baseline = evotor.BaselinePrevious
+		}
+		return baseFrom, baseTo, baseline, err
+	}
+	baseFrom, err := getTimeArg(args, "compare_from", from.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, "", err
+	}
+	baseTo, err := getTimeArg(args, "compare_to", from.Location())
+	if err != nil {
+		return time.Time{}, time.Time{}, "", err
 	}
-	return parsed, nil
+	return baseFrom, baseTo, "", nil
+}
+
+func getLocationArg(args map[string]any, key string) (*time.Location, error) {
+	value, ok := getStringArg(args, key)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return loc, nil
+}
+
+func getDocumentTypeArg(args map[string]any, key string) (evotor.DocumentType, error) {
+	value, _ := getStringArg(args, key)
+	return evotor.ParseDocumentType(value)
 }
 
 func getStoreIDArg(args map[string]any, fallback string) string {
@@ -283,33 +617,33 @@ func toolErrorPayload(message string) string {
 }
 
 func filterDocumentsByItem(ctx context.Context, logger *zap.Logger, evotorClient *evotor.Client, storeID string, documents []evotor.DocumentShort, itemQuery string) ([]evotor.DocumentShort, error) {
-	needle := strings.ToLower(strings.TrimSpace(itemQuery))
-	if needle == "" {
+	matcher := match.New(itemQuery)
+	if matcher.Empty() {
 		return documents, nil
 	}
 
-	matches := make([]evotor.DocumentShort, 0, defaultOutputLimit)
+	ids := make([]string, 0, len(documents))
 	for _, doc := range documents {
-		fullDoc, _, err := trackCall(logger, "GetDocument", map[string]any{"doc_id": doc.ID}, func() (evotor.DocumentFull, error) {
-			return evotorClient.GetDocument(ctx, doc.ID, optionalString(storeID))
+		ids = append(ids, doc.ID)
+	}
+
+	args := map[string]any{"documents": len(ids), "item_query": itemQuery}
+	fullDocs, _, err := trackCall(logger, "FetchDocuments", args, func() ([]evotor.DocumentFull, error) {
+		// The whole page is checked: next_cursor resumes after it, so a
+		// document left unchecked here would never be seen.
+		return evotorClient.FetchDocuments(ctx, ids, optionalString(storeID), evotor.FetchOptions{
+			Match: func(doc evotor.DocumentFull) bool {
+				return documentHasItem(doc, matcher)
+			},
 		})
-		if err != nil {
-			return nil, err
-		}
-		if documentHasItem(fullDoc, needle) {
-			matches = append(matches, evotor.DocumentShort{
-				ID:        fullDoc.ID,
-				Type:      fullDoc.Type,
-				CloseDate: fullDoc.CloseDate,
-				DeviceID:  fullDoc.DeviceID,
-				StoreID:   fullDoc.StoreID,
-				Body:      fullDoc.Body,
-				Total:     fullDoc.Total,
-			})
-			if len(matches) >= defaultOutputLimit {
-				break
-			}
-		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]evotor.DocumentShort, 0, len(fullDocs))
+	for _, fullDoc := range fullDocs {
+		matches = append(matches, fullDoc.Short())
 	}
 	return matches, nil
 }
@@ -3,16 +3,28 @@ package cli
 import "time"
 
 type Options struct {
-	Query         string
-	EvotorToken   string
-	EvotorStoreID string
-	From          string
-	To            string
-	JSON          bool
-	Debug         bool
-	LogFile       string
-	Timeout       time.Duration
-	LLMBaseURL    string
-	LLMAPIKey     string
-	LLMModel      string
+	Query            string
+	EvotorToken      string
+	EvotorStoreID    string
+	EvotorBaseURL    string
+	StoreTimezone    string
+	From             string
+	To               string
+	JSON             bool
+	Raw              bool
+	Debug            bool
+	LogFile          string
+	Timeout          time.Duration
+	CacheDir         string
+	CatalogTTL       time.Duration
+	EvotorRPS        float64
+	EvotorBurst      int
+	RetryMaxAttempts int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+	RetryAfterMax    time.Duration
+	FetchConcurrency int
+	LLMBaseURL       string
+	LLMAPIKey        string
+	LLMModel         string
 }
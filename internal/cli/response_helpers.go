@@ -32,7 +32,7 @@ func writeResults(results any) {
 		for i, item := range v {
 			fmt.Fprintf(os.Stdout, "%d) %s (id=%s", i+1, item.Name, item.ID)
 			if item.Price != 0 {
-				fmt.Fprintf(os.Stdout, ", цена=%.2f", item.Price)
+				fmt.Fprintf(os.Stdout, ", цена=%s", item.Price)
 			}
 			if item.ArticleNumber != "" {
 				fmt.Fprintf(os.Stdout, ", артикул=%s", item.ArticleNumber)
@@ -48,7 +48,7 @@ func writeResults(results any) {
 			return
 		}
 		for i, doc := range v {
-			fmt.Fprintf(os.Stdout, "%d) doc_id=%s, дата=%s, сумма=%.2f", i+1, doc.ID, doc.Timestamp, doc.Total)
+			fmt.Fprintf(os.Stdout, "%d) doc_id=%s, дата=%s, сумма=%s", i+1, doc.ID, doc.Timestamp, doc.Total)
 			if doc.StoreID != "" {
 				fmt.Fprintf(os.Stdout, ", store=%s", doc.StoreID)
 			}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"simple_answer_llm/internal/evotor"
+
+	"go.uber.org/zap"
+)
+
+func runSync(opts *Options, args []string, logger *zap.Logger) error {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("usage: evotor-ai sync products [flags]")
+	}
+	target := args[0]
+
+	var full bool
+	fs := flag.NewFlagSet("evotor-ai sync "+target, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [flags]\n", fs.Name())
+		fs.PrintDefaults()
+	}
+
+	fs.StringVar(&opts.EvotorToken, "token", opts.EvotorToken, "Evotor API token (EVOTOR_TOKEN)")
+	fs.StringVar(&opts.EvotorStoreID, "store-id", opts.EvotorStoreID, "Evotor store ID (EVOTOR_STORE_ID)")
+	fs.StringVar(&opts.EvotorBaseURL, "evotor-base-url", opts.EvotorBaseURL, "Evotor API base URL (EVOTOR_BASE_URL)")
+	fs.StringVar(&opts.CacheDir, "cache-dir", opts.CacheDir, "Local cache directory (CACHE_DIR)")
+	fs.BoolVar(&full, "full", false, "Drop the local copy and download everything again")
+	fs.BoolVar(&opts.JSON, "json", false, "Output JSON format")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return nil
+		}
+		return err
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+
+	evotorClient := newEvotorClientFromOptions(opts, logger)
+
+	switch target {
+	case "products":
+		result, err := evotorClient.SyncProducts(ctx, optionalString(opts.EvotorStoreID), full)
+		if err != nil {
+			return fmt.Errorf("sync products: %s", friendlyEvotorError(err))
+		}
+		return writeSyncResult(opts, result)
+	default:
+		return fmt.Errorf("unknown sync target: %s", target)
+	}
+}
+
+func writeSyncResult(opts *Options, result evotor.SyncResult) error {
+	if opts.JSON {
+		return json.NewEncoder(os.Stdout).Encode(result)
+	}
+
+	mode := "инкрементальная"
+	if result.Full {
+		mode = "полная"
+	}
+	fmt.Fprintf(os.Stdout, "Каталог %s синхронизирован (%s): получено %d, всего %d, групп %d.\n", result.StoreID, mode, result.Fetched, result.Total, result.Groups)
+	return nil
+}
@@ -1,15 +1,16 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
 	"simple_answer_llm/internal/evotor"
+	"simple_answer_llm/internal/match"
 
 	"go.uber.org/zap"
 )
@@ -41,24 +42,26 @@ type toolCallRecord struct {
 	MS   int64          `json:"ms"`
 	OK   bool           `json:"ok"`
 	Err  string         `json:"err,omitempty"`
+	// raw is the Evotor JSON of a fetched document, shown with --raw.
+	raw json.RawMessage
 }
 
 type resultItem struct {
-	ID            string   `json:"item_id"`
-	Name          string   `json:"name"`
-	Price         float64  `json:"price,omitempty"`
-	Code          string   `json:"code,omitempty"`
-	Barcodes      []string `json:"barcodes,omitempty"`
-	ArticleNumber string   `json:"article_number,omitempty"`
-	MeasureName   string   `json:"measure_name,omitempty"`
+	ID            string       `json:"item_id"`
+	Name          string       `json:"name"`
+	Price         evotor.Money `json:"price,omitempty"`
+	Code          string       `json:"code,omitempty"`
+	Barcodes      []string     `json:"barcodes,omitempty"`
+	ArticleNumber string       `json:"article_number,omitempty"`
+	MeasureName   string       `json:"measure_name,omitempty"`
 }
 
 type resultDocument struct {
-	ID        string  `json:"doc_id"`
-	Timestamp string  `json:"timestamp"`
-	Total     float64 `json:"total"`
-	StoreID   string  `json:"store_id,omitempty"`
-	DeviceID  string  `json:"device_id,omitempty"`
+	ID        string       `json:"doc_id"`
+	Timestamp string       `json:"timestamp"`
+	Total     evotor.Money `json:"total"`
+	StoreID   string       `json:"store_id,omitempty"`
+	DeviceID  string       `json:"device_id,omitempty"`
 }
 
 type periodRange struct {
@@ -97,6 +100,12 @@ func trackCall[T any](logger *zap.Logger, name string, args map[string]any, fn f
 	return result, record, err
 }
 
+// failedCall records a tool call rejected before it ran, e.g. for invalid
+// arguments.
+func failedCall(name string, args map[string]any, err error) (any, toolCallRecord, error) {
+	return nil, toolCallRecord{Name: name, Args: args, OK: false, Err: err.Error()}, err
+}
+
 func friendlyEvotorError(err error) string {
 	switch {
 	case errors.Is(err, evotor.ErrMissingToken):
@@ -107,6 +116,10 @@ func friendlyEvotorError(err error) string {
 		return "Нет доступа: неверный токен или недостаточно прав."
 	case errors.Is(err, evotor.ErrRateLimited):
 		return "Слишком много запросов. Попробуйте позже."
+	case errors.Is(err, evotor.ErrCategoryNotFound):
+		return "Категория не найдена: уточните название группы товаров."
+	case errors.Is(err, evotor.ErrInvalidCursor):
+		return "Не удалось продолжить список: запросите документы заново."
 	default:
 		if err == nil {
 			return ""
@@ -123,11 +136,9 @@ func optionalString(value string) *string {
 	return &trimmed
 }
 
-func documentHasItem(doc evotor.DocumentFull, needle string) bool {
+func documentHasItem(doc evotor.DocumentFull, matcher *match.Matcher) bool {
 	for _, pos := range doc.Body.Positions {
-		name := strings.ToLower(strings.TrimSpace(pos.Name))
-		productName := strings.ToLower(strings.TrimSpace(pos.ProductName))
-		if (name != "" && strings.Contains(name, needle)) || (productName != "" && strings.Contains(productName, needle)) {
+		if matcher.Matches(pos.Name) || (pos.ProductName != "" && matcher.Matches(pos.ProductName)) {
 			return true
 		}
 	}
@@ -164,84 +175,41 @@ func extractQuoted(query string) string {
 	return ""
 }
 
-func resolvePeriod(query string, opts *Options, interactive bool) (periodRange, string, error) {
-	if opts.From != "" || opts.To != "" {
-		return parsePeriodFromFlags(opts)
-	}
-
-	now := time.Now()
-	lower := strings.ToLower(query)
-
-	switch {
-	case strings.Contains(lower, "вчера"):
-		start := startOfDay(now.AddDate(0, 0, -1))
-		end := endOfDay(now.AddDate(0, 0, -1))
-		return periodRange{From: start, To: end}, "", nil
-	case strings.Contains(lower, "сегодня"):
-		start := startOfDay(now)
-		return periodRange{From: start, To: now}, "", nil
-	case strings.Contains(lower, "позавчера"):
-		start := startOfDay(now.AddDate(0, 0, -2))
-		end := endOfDay(now.AddDate(0, 0, -2))
-		return periodRange{From: start, To: end}, "", nil
-	case strings.Contains(lower, "недел"):
-		to := now
-		from := now.AddDate(0, 0, -defaultPeriodDays)
-		return periodRange{From: from, To: to}, "", nil
-	}
-
-	month, hasMonth := detectMonth(lower)
-	if hasMonth {
-		year, hasYear := detectYear(lower)
-		if !hasYear {
-			year = now.Year()
-			from := time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
-			to := endOfDay(from.AddDate(0, 1, -1))
-			return periodRange{From: from, To: to}, fmt.Sprintf("Год не указан, использован %d.", year), nil
-		}
-		from := time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
-		to := endOfDay(from.AddDate(0, 1, -1))
-		return periodRange{From: from, To: to}, "", nil
-	}
-
-	to := now
-	from := now.AddDate(0, 0, -defaultPeriodDays)
-	return periodRange{From: from, To: to}, "Период не указан, использованы последние 7 дней.", nil
-}
-
-func parsePeriodFromFlags(opts *Options) (periodRange, string, error) {
+// parsePeriodFromFlags reads --from/--to as whole days in loc. A missing
+// bound defaults to the last defaultPeriodDays days before now.
+func parsePeriodFromFlags(opts *Options, loc *time.Location, now time.Time) (periodRange, error) {
 	var from time.Time
 	var to time.Time
 	var err error
 
 	if opts.From != "" {
-		from, err = parseDate(opts.From)
+		from, err = parseDate(opts.From, loc)
 		if err != nil {
-			return periodRange{}, "", fmt.Errorf("invalid --from date: %w", err)
+			return periodRange{}, fmt.Errorf("invalid --from date: %w", err)
 		}
 		from = startOfDay(from)
 	}
 	if opts.To != "" {
-		to, err = parseDate(opts.To)
+		to, err = parseDate(opts.To, loc)
 		if err != nil {
-			return periodRange{}, "", fmt.Errorf("invalid --to date: %w", err)
+			return periodRange{}, fmt.Errorf("invalid --to date: %w", err)
 		}
 		to = endOfDay(to)
 	}
 	if from.IsZero() {
-		from = time.Now().AddDate(0, 0, -defaultPeriodDays)
+		from = now.In(loc).AddDate(0, 0, -defaultPeriodDays)
 	}
 	if to.IsZero() {
-		to = time.Now()
+		to = now.In(loc)
 	}
 	if to.Before(from) {
-		return periodRange{}, "", errors.New("--to must be after --from")
+		return periodRange{}, errors.New("--to must be after --from")
 	}
-	return periodRange{From: from, To: to}, "", nil
+	return periodRange{From: from, To: to}, nil
 }
 
-func parseDate(value string) (time.Time, error) {
-	return time.ParseInLocation("2006-01-02", value, time.Local)
+func parseDate(value string, loc *time.Location) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", value, loc)
 }
 
 func startOfDay(t time.Time) time.Time {
@@ -252,44 +220,6 @@ func endOfDay(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, int(time.Second-time.Nanosecond), t.Location())
 }
 
-func detectMonth(lower string) (time.Month, bool) {
-	months := map[string]time.Month{
-		"январ":   time.January,
-		"феврал":  time.February,
-		"март":    time.March,
-		"апрел":   time.April,
-		"мая":     time.May,
-		"май":     time.May,
-		"июн":     time.June,
-		"июл":     time.July,
-		"август":  time.August,
-		"сентябр": time.September,
-		"октябр":  time.October,
-		"ноябр":   time.November,
-		"декабр":  time.December,
-	}
-
-	for key, month := range months {
-		if strings.Contains(lower, key) {
-			return month, true
-		}
-	}
-	return 0, false
-}
-
-func detectYear(lower string) (int, bool) {
-	re := regexp.MustCompile(`\b(20\d{2})\b`)
-	match := re.FindStringSubmatch(lower)
-	if len(match) < 2 {
-		return 0, false
-	}
-	year, err := strconv.Atoi(match[1])
-	if err != nil {
-		return 0, false
-	}
-	return year, true
-}
-
 func min(a, b int) int {
 	if a < b {
 		return a
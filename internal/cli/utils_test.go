@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriodFromFlags(t *testing.T) {
+	vladivostok := time.FixedZone("UTC+10:00", 10*3600)
+	now := time.Date(2026, 3, 10, 1, 0, 0, 0, time.UTC) // 11:00 in Vladivostok
+
+	period, err := parsePeriodFromFlags(&Options{From: "2026-03-01", To: "2026-03-07"}, vladivostok, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantFrom := time.Date(2026, 3, 1, 0, 0, 0, 0, vladivostok)
+	wantTo := time.Date(2026, 3, 7, 23, 59, 59, int(time.Second-time.Nanosecond), vladivostok)
+	if !period.From.Equal(wantFrom) || !period.To.Equal(wantTo) {
+		t.Errorf("period = %v..%v, want %v..%v", period.From, period.To, wantFrom, wantTo)
+	}
+
+	period, err = parsePeriodFromFlags(&Options{From: "2026-03-09"}, vladivostok, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !period.To.Equal(now) || period.To.Location() != vladivostok {
+		t.Errorf("open end = %v, want now in the store's zone", period.To)
+	}
+
+	for _, opts := range []Options{{From: "01.03.2026"}, {To: "2026-02-30"}, {From: "2026-03-07", To: "2026-03-01"}} {
+		if _, err := parsePeriodFromFlags(&opts, vladivostok, now); err == nil {
+			t.Errorf("%+v: want an error", opts)
+		}
+	}
+}
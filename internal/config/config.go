@@ -8,21 +8,41 @@ import (
 )
 
 type Config struct {
-	EvotorToken   string        `koanf:"evotor_token"`
-	EvotorStoreID string        `koanf:"evotor_store_id"`
-	LLMBaseURL    string        `koanf:"llm_base_url"`
-	LLMAPIKey     string        `koanf:"llm_api_key"`
-	LLMModel      string        `koanf:"llm_model"`
-	Timeout       time.Duration `koanf:"timeout"`
-	LogFile       string        `koanf:"log_file"`
-	Debug         bool          `koanf:"debug"`
+	EvotorToken      string        `koanf:"evotor_token"`
+	EvotorStoreID    string        `koanf:"evotor_store_id"`
+	EvotorBaseURL    string        `koanf:"evotor_base_url"`
+	StoreTimezone    string        `koanf:"store_timezone"`
+	LLMBaseURL       string        `koanf:"llm_base_url"`
+	LLMAPIKey        string        `koanf:"llm_api_key"`
+	LLMModel         string        `koanf:"llm_model"`
+	Timeout          time.Duration `koanf:"timeout"`
+	CacheDir         string        `koanf:"cache_dir"`
+	CatalogTTL       time.Duration `koanf:"catalog_ttl"`
+	EvotorRPS        float64       `koanf:"evotor_rps"`
+	EvotorBurst      int           `koanf:"evotor_burst"`
+	RetryMaxAttempts int           `koanf:"retry_max_attempts"`
+	RetryBaseDelay   time.Duration `koanf:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `koanf:"retry_max_delay"`
+	RetryAfterMax    time.Duration `koanf:"retry_after_max"`
+	FetchConcurrency int           `koanf:"fetch_concurrency"`
+	LogFile          string        `koanf:"log_file"`
+	Debug            bool          `koanf:"debug"`
 }
 
 func New() (Config, error) {
 	cfg := Config{
-		Timeout: 20 * time.Second,
-		LogFile: "./evotor-ai.log",
-		Debug:   false,
+		Timeout:          20 * time.Second,
+		CacheDir:         "./.evotor-cache",
+		CatalogTTL:       time.Hour,
+		EvotorRPS:        5,
+		EvotorBurst:      5,
+		RetryMaxAttempts: 4,
+		RetryBaseDelay:   500 * time.Millisecond,
+		RetryMaxDelay:    10 * time.Second,
+		RetryAfterMax:    2 * time.Minute,
+		FetchConcurrency: 4,
+		LogFile:          "./evotor-ai.log",
+		Debug:            false,
 	}
 
 	if err := coreconfig.Load(&cfg); err != nil {
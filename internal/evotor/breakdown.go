@@ -0,0 +1,182 @@
+package evotor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const maxBreakdownBuckets = 1000
+
+type Bucket string
+
+const (
+	BucketHour  Bucket = "hour"
+	BucketDay   Bucket = "day"
+	BucketWeek  Bucket = "week"
+	BucketMonth Bucket = "month"
+)
+
+func ParseBucket(value string) (Bucket, error) {
+	switch bucket := Bucket(strings.ToLower(strings.TrimSpace(value))); bucket {
+	case BucketHour, BucketDay, BucketWeek, BucketMonth:
+		return bucket, nil
+	case "":
+		return BucketDay, nil
+	default:
+		return "", fmt.Errorf("unsupported bucket %q: use hour, day, week or month", value)
+	}
+}
+
+// start returns the beginning of the bucket containing t, in t's location.
+// Weeks start on Monday.
+func (b Bucket) start(t time.Time) time.Time {
+	switch b {
+	case BucketHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case BucketWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case BucketMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+func (b Bucket) next(start time.Time) time.Time {
+	switch b {
+	case BucketHour:
+		return start.Add(time.Hour)
+	case BucketWeek:
+		return start.AddDate(0, 0, 7)
+	case BucketMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+type BreakdownOptions struct {
+	Bucket Bucket
+	// DocumentType filters documents; see DocumentType.
+	DocumentType DocumentType
+	// Location defines bucket boundaries; nil uses the store's timezone.
+	Location *time.Location
+	// Category counts only positions of this group (ID or name) and its
+	// subgroups; receipts without such positions are left out.
+	Category string
+}
+
+func (c *Client) GetSalesBreakdown(ctx context.Context, from, to time.Time, storeID *string, opts BreakdownOptions) (SalesBreakdown, error) {
+	if !c.hasToken() {
+		return SalesBreakdown{}, ErrMissingToken
+	}
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return SalesBreakdown{}, fmt.Errorf("breakdown needs a period with from before to")
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return SalesBreakdown{}, err
+	}
+
+	bucket, err := ParseBucket(string(opts.Bucket))
+	if err != nil {
+		return SalesBreakdown{}, err
+	}
+	loc := opts.Location
+	if loc == nil {
+		if loc, err = c.storeLocation(ctx, resolvedStoreID); err != nil {
+			return SalesBreakdown{}, err
+		}
+	}
+	documentType := opts.DocumentType.orDefault()
+
+	categories, group, err := c.categoryFilter(ctx, resolvedStoreID, opts.Category, false)
+	if err != nil {
+		return SalesBreakdown{}, err
+	}
+
+	localFrom, localTo := from.In(loc), to.In(loc)
+	var buckets []SalesBucket
+	index := map[time.Time]int{}
+	for start := bucket.start(localFrom); !start.After(localTo); start = bucket.next(start) {
+		if len(buckets) >= maxBreakdownBuckets {
+			return SalesBreakdown{}, fmt.Errorf("period is too long for %s buckets (max %d)", bucket, maxBreakdownBuckets)
+		}
+		index[start] = len(buckets)
+		buckets = append(buckets, SalesBucket{Start: start.Format(time.RFC3339)})
+	}
+
+	result := SalesBreakdown{
+		StoreID:  resolvedStoreID,
+		From:     localFrom.Format(time.RFC3339),
+		To:       localTo.Format(time.RFC3339),
+		Bucket:   bucket,
+		Timezone: loc.String(),
+	}
+	if categories != nil {
+		result.Category = categories.path(group.ID)
+	}
+	for doc, err := range c.documentsSeq(ctx, resolvedStoreID, from, to) {
+		if err != nil {
+			return SalesBreakdown{}, err
+		}
+		if !documentType.matches(doc.Type) {
+			continue
+		}
+		closed, err := ParseTime(doc.CloseDate)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		i, ok := index[bucket.start(closed.In(loc))]
+		if !ok {
+			continue
+		}
+		revenue := doc.Total
+		if categories != nil {
+			var matched bool
+			revenue, matched = categoryRevenue(doc, categories, group.ID)
+			if !matched {
+				continue
+			}
+		}
+		buckets[i].Count++
+		buckets[i].Revenue += revenue
+		result.Count++
+		result.Revenue += revenue
+	}
+
+	for i := range buckets {
+		buckets[i].AverageReceipt = averageReceipt(buckets[i].Revenue, buckets[i].Count)
+	}
+	result.Buckets = buckets
+	result.AverageReceipt = averageReceipt(result.Revenue, result.Count)
+	return result, nil
+}
+
+// categoryRevenue sums positions of the document that belong to groupID.
+func categoryRevenue(doc DocumentFull, categories *categoryIndex, groupID string) (Money, bool) {
+	var revenue Money
+	var matched bool
+	for _, pos := range doc.Body.Positions {
+		if categories.contains(groupID, pos.ProductID) {
+			revenue += pos.Revenue()
+			matched = true
+		}
+	}
+	return revenue, matched
+}
+
+func averageReceipt(revenue Money, count int) Money {
+	return revenue.Div(count)
+}
+
+// round2 rounds shares and quantities to two decimals; amounts are Money.
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
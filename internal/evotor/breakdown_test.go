@@ -0,0 +1,159 @@
+package evotor_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/config"
+	"simple_answer_llm/internal/evotor"
+)
+
+// breakdownDocuments are receipts of a Vladivostok (UTC+10) store. Local
+// close times: a Mar 1 06:00, b Mar 1 23:00, payback Mar 2 00:00, c Mar 2
+// 01:00, d Mar 4 00:30, f Mar 9 12:00 (Monday).
+const breakdownDocuments = `[
+{"id": "a", "type": "SELL", "close_date": "2026-02-28T20:00:00.000+0000", "body": {"result_sum": 100, "positions": [{"product_id": "p-milk", "result_sum": 100}]}},
+{"id": "b", "type": "SELL", "close_date": "2026-03-01T13:00:00.000+0000", "body": {"result_sum": 200, "positions": [{"product_id": "p-lak", "result_sum": 200}]}},
+{"id": "payback", "type": "PAYBACK", "close_date": "2026-03-01T14:00:00.000+0000", "body": {"result_sum": 999}},
+{"id": "c", "type": "SELL", "close_date": "2026-03-01T15:00:00.000+0000", "body": {"result_sum": 300, "positions": [{"product_id": "p-milk", "result_sum": 50}, {"product_id": "p-lak", "result_sum": 250}]}},
+{"id": "d", "type": "SELL", "close_date": "2026-03-03T14:30:00.000+0000", "body": {"result_sum": 400, "positions": [{"product_id": "p-lak", "result_sum": 400}]}},
+{"id": "f", "type": "SELL", "close_date": "2026-03-09T02:00:00.000+0000", "body": {"result_sum": 500, "positions": [{"product_id": "p-milk", "result_sum": 500}]}}
+]`
+
+func newBreakdownClient(t *testing.T) (*evotor.Client, *time.Location) {
+	t.Helper()
+	client, _ := newStoreClient(t, map[string]string{
+		"stores/s1/documents.json":      breakdownDocuments,
+		"stores/s1/products.json":       testProducts,
+		"stores/s1/product-groups.json": testGroups,
+	}, func(cfg *config.Config) {
+		cfg.StoreTimezone = "Asia/Vladivostok"
+	})
+	loc, err := time.LoadLocation("Asia/Vladivostok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, loc
+}
+
+type wantBucket struct {
+	start   string
+	count   int
+	revenue evotor.Money
+}
+
+func checkBuckets(t *testing.T, got evotor.SalesBreakdown, want []wantBucket) {
+	t.Helper()
+	if len(got.Buckets) != len(want) {
+		t.Fatalf("buckets = %+v, want %d", got.Buckets, len(want))
+	}
+	var count int
+	var revenue evotor.Money
+	for i, w := range want {
+		b := got.Buckets[i]
+		if b.Start != w.start || b.Count != w.count || b.Revenue != w.revenue {
+			t.Errorf("bucket %d = %s %d %s, want %s %d %s", i, b.Start, b.Count, b.Revenue, w.start, w.count, w.revenue)
+		}
+		count += w.count
+		revenue += w.revenue
+	}
+	if got.Count != count || got.Revenue != revenue {
+		t.Errorf("total = %d %s, want %d %s", got.Count, got.Revenue, count, revenue)
+	}
+}
+
+func TestSalesBreakdownByDayInStoreTimezone(t *testing.T) {
+	client, loc := newBreakdownClient(t)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 3, 4, 23, 59, 59, 0, loc)
+
+	got, err := client.GetSalesBreakdown(context.Background(), from, to, nil, evotor.BreakdownOptions{Bucket: evotor.BucketDay})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Timezone != "Asia/Vladivostok" {
+		t.Errorf("timezone = %s, want the store's", got.Timezone)
+	}
+	// b closes before and c after midnight in Vladivostok, both on Mar 1
+	// in UTC; Mar 3 has no sales and is still reported.
+	checkBuckets(t, got, []wantBucket{
+		{"2026-03-01T00:00:00+10:00", 2, 30000},
+		{"2026-03-02T00:00:00+10:00", 1, 30000},
+		{"2026-03-03T00:00:00+10:00", 0, 0},
+		{"2026-03-04T00:00:00+10:00", 1, 40000},
+	})
+	if got.Buckets[2].AverageReceipt != 0 || got.Buckets[0].AverageReceipt != 15000 {
+		t.Errorf("average receipts = %s, %s; want 150.00 and 0.00", got.Buckets[0].AverageReceipt, got.Buckets[2].AverageReceipt)
+	}
+}
+
+func TestSalesBreakdownByWeekStartsOnMonday(t *testing.T) {
+	client, loc := newBreakdownClient(t)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, loc) // Sunday
+	to := time.Date(2026, 3, 10, 23, 59, 59, 0, loc)
+
+	got, err := client.GetSalesBreakdown(context.Background(), from, to, nil, evotor.BreakdownOptions{Bucket: evotor.BucketWeek})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBuckets(t, got, []wantBucket{
+		{"2026-02-23T00:00:00+10:00", 2, 30000},
+		{"2026-03-02T00:00:00+10:00", 2, 70000},
+		{"2026-03-09T00:00:00+10:00", 1, 50000},
+	})
+}
+
+func TestSalesBreakdownByMonth(t *testing.T) {
+	client, loc := newBreakdownClient(t)
+	from := time.Date(2026, 2, 15, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 4, 10, 23, 59, 59, 0, loc)
+
+	got, err := client.GetSalesBreakdown(context.Background(), from, to, nil, evotor.BreakdownOptions{Bucket: evotor.BucketMonth})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a closes on Feb 28 in UTC but on Mar 1 in the store.
+	checkBuckets(t, got, []wantBucket{
+		{"2026-02-01T00:00:00+10:00", 0, 0},
+		{"2026-03-01T00:00:00+10:00", 5, 150000},
+		{"2026-04-01T00:00:00+10:00", 0, 0},
+	})
+}
+
+func TestSalesBreakdownTooManyBuckets(t *testing.T) {
+	client, loc := newBreakdownClient(t)
+	from := time.Date(2026, 2, 15, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 4, 10, 23, 59, 59, 0, loc)
+
+	_, err := client.GetSalesBreakdown(context.Background(), from, to, nil, evotor.BreakdownOptions{Bucket: evotor.BucketHour})
+	if err == nil || !strings.Contains(err.Error(), "too long") {
+		t.Fatalf("err = %v, want the bucket limit error", err)
+	}
+}
+
+func TestSalesBreakdownCategory(t *testing.T) {
+	client, loc := newBreakdownClient(t)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2026, 3, 4, 23, 59, 59, 0, loc)
+
+	// Milk is in Молочные, a subgroup of Продукты; only its positions count.
+	got, err := client.GetSalesBreakdown(context.Background(), from, to, nil, evotor.BreakdownOptions{Bucket: evotor.BucketDay, Category: "продукты"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Category != "Продукты" {
+		t.Errorf("category = %q, want Продукты", got.Category)
+	}
+	checkBuckets(t, got, []wantBucket{
+		{"2026-03-01T00:00:00+10:00", 1, 10000},
+		{"2026-03-02T00:00:00+10:00", 1, 5000},
+		{"2026-03-03T00:00:00+10:00", 0, 0},
+		{"2026-03-04T00:00:00+10:00", 0, 0},
+	})
+
+	if _, err := client.GetSalesBreakdown(context.Background(), from, to, nil, evotor.BreakdownOptions{Category: "Игрушки"}); err == nil {
+		t.Error("unknown category: want an error")
+	}
+}
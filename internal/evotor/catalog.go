@@ -0,0 +1,159 @@
+package evotor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// catalogSyncOverlap re-requests a small window before the last sync so
+// products updated while the previous sync was running are not missed.
+const catalogSyncOverlap = time.Minute
+
+// catalogFullSyncInterval bounds how long incremental syncs are trusted:
+// they cannot see products deleted in Evotor, so a full sync runs at least
+// this often and drops them.
+const catalogFullSyncInterval = 24 * time.Hour
+
+// catalogCacheVersion is bumped whenever cached items gain fields; copies
+// written by older versions are replaced by a full sync. Unversioned (0)
+// copies lack the group fields (ParentID, Group) and stock balances
+// (Quantity, CostPrice); 1 has both.
+const catalogCacheVersion = 1
+
+type catalogSnapshot struct {
+	Version  int       `json:"version"`
+	StoreID  string    `json:"store_id"`
+	SyncedAt time.Time `json:"synced_at"`
+	// FullSyncedAt is when the items were last replaced by a full sync.
+	FullSyncedAt time.Time      `json:"full_synced_at,omitempty"`
+	Items        []Item         `json:"items"`
+	Groups       []ProductGroup `json:"groups,omitempty"`
+}
+
+func (s catalogSnapshot) stale(ttl time.Duration, now time.Time) bool {
+	if s.SyncedAt.IsZero() {
+		return true
+	}
+	return ttl <= 0 || now.Sub(s.SyncedAt) >= ttl
+}
+
+func (s catalogSnapshot) fullSyncDue(now time.Time) bool {
+	return s.FullSyncedAt.IsZero() || now.Sub(s.FullSyncedAt) >= catalogFullSyncInterval
+}
+
+func (s catalogSnapshot) merge(updated []Item) catalogSnapshot {
+	index := make(map[string]int, len(s.Items))
+	items := make([]Item, 0, len(s.Items)+len(updated))
+	for _, item := range s.Items {
+		index[item.ID] = len(items)
+		items = append(items, item)
+	}
+	for _, item := range updated {
+		if i, ok := index[item.ID]; ok {
+			items[i] = item
+			continue
+		}
+		index[item.ID] = len(items)
+		items = append(items, item)
+	}
+	s.Items = items
+	return s
+}
+
+// catalogCache keeps product snapshots per store in memory and, when dir is
+// set, persists them as <dir>/catalog/<store_id>.json.
+type catalogCache struct {
+	dir string
+	ttl time.Duration
+
+	mu        sync.Mutex
+	snapshots map[string]catalogSnapshot
+}
+
+func newCatalogCache(dir string, ttl time.Duration) *catalogCache {
+	return &catalogCache{
+		dir:       dir,
+		ttl:       ttl,
+		snapshots: map[string]catalogSnapshot{},
+	}
+}
+
+func (c *catalogCache) load(storeID string) (catalogSnapshot, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if snapshot, ok := c.snapshots[storeID]; ok {
+		return snapshot, true, nil
+	}
+	if c.dir == "" {
+		return catalogSnapshot{}, false, nil
+	}
+
+	data, err := os.ReadFile(c.path(storeID))
+	if errors.Is(err, os.ErrNotExist) {
+		return catalogSnapshot{}, false, nil
+	}
+	if err != nil {
+		return catalogSnapshot{}, false, fmt.Errorf("read catalog cache: %w", err)
+	}
+	var snapshot catalogSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return catalogSnapshot{}, false, fmt.Errorf("decode catalog cache: %w", err)
+	}
+	if snapshot.Version != catalogCacheVersion {
+		return catalogSnapshot{}, false, nil
+	}
+	c.snapshots[storeID] = snapshot
+	return snapshot, true, nil
+}
+
+func (c *catalogCache) save(snapshot catalogSnapshot) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot.Version = catalogCacheVersion
+	c.snapshots[snapshot.StoreID] = snapshot
+	if c.dir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("encode catalog cache: %w", err)
+	}
+	return writeFileAtomic(c.path(snapshot.StoreID), data)
+}
+
+func (c *catalogCache) path(storeID string) string {
+	return filepath.Join(c.dir, "catalog", url.PathEscape(storeID)+".json")
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create cache file: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("write cache file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("replace cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package evotor
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCatalogFullSyncDue(t *testing.T) {
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		fullSyncedAt time.Time
+		want         bool
+	}{
+		{"never", time.Time{}, true},
+		{"recent", now.Add(-time.Hour), false},
+		{"just before interval", now.Add(-catalogFullSyncInterval + time.Second), false},
+		{"interval passed", now.Add(-catalogFullSyncInterval), true},
+	}
+	for _, tt := range tests {
+		snapshot := catalogSnapshot{SyncedAt: now.Add(-time.Minute), FullSyncedAt: tt.fullSyncedAt}
+		if got := snapshot.fullSyncDue(now); got != tt.want {
+			t.Errorf("%s: fullSyncDue = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogMergeReplacesAndAppends(t *testing.T) {
+	snapshot := catalogSnapshot{Items: []Item{{ID: "a", Name: "old"}, {ID: "b", Name: "b"}}}
+	merged := snapshot.merge([]Item{{ID: "a", Name: "new"}, {ID: "c", Name: "c"}})
+
+	var names []string
+	for _, item := range merged.Items {
+		names = append(names, item.ID+"="+item.Name)
+	}
+	want := []string{"a=new", "b=b", "c=c"}
+	if len(names) != len(want) {
+		t.Fatalf("items = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("items = %v, want %v", names, want)
+		}
+	}
+}
+
+func TestCatalogCacheDropsOtherVersions(t *testing.T) {
+	dir := t.TempDir()
+	for _, version := range []int{0, catalogCacheVersion + 1} {
+		data, err := json.Marshal(catalogSnapshot{Version: version, StoreID: "s1", SyncedAt: time.Now(), Items: []Item{{ID: "a"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writeFileAtomic(newCatalogCache(dir, time.Hour).path("s1"), data); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok, err := newCatalogCache(dir, time.Hour).load("s1"); err != nil || ok {
+			t.Errorf("version %d: load ok = %v, err = %v, want a miss", version, ok, err)
+		}
+	}
+
+	if err := newCatalogCache(dir, time.Hour).save(catalogSnapshot{StoreID: "s1", Items: []Item{{ID: "a"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if snapshot, ok, err := newCatalogCache(dir, time.Hour).load("s1"); err != nil || !ok || len(snapshot.Items) != 1 {
+		t.Errorf("current version: load = %+v, %v, %v, want the saved snapshot", snapshot, ok, err)
+	}
+}
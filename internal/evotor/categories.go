@@ -0,0 +1,253 @@
+package evotor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const uncategorizedName = "Без категории"
+
+// categoryIndex resolves the product group tree of a catalog snapshot. Groups
+// come from /product-groups and from catalog entries flagged as groups.
+type categoryIndex struct {
+	groups   map[string]ProductGroup
+	children map[string][]string
+	parentOf map[string]string
+}
+
+func newCategoryIndex(snapshot catalogSnapshot) *categoryIndex {
+	idx := &categoryIndex{
+		groups:   map[string]ProductGroup{},
+		children: map[string][]string{},
+		parentOf: map[string]string{},
+	}
+	for _, group := range snapshot.Groups {
+		idx.groups[group.ID] = group
+	}
+	for _, item := range snapshot.Items {
+		if item.Group {
+			if _, ok := idx.groups[item.ID]; !ok {
+				idx.groups[item.ID] = ProductGroup{ID: item.ID, Name: item.Name, ParentID: item.ParentID}
+			}
+			continue
+		}
+		idx.parentOf[item.ID] = item.ParentID
+		// Products may point at a group the API did not return.
+		if _, ok := idx.groups[item.ParentID]; item.ParentID != "" && !ok {
+			idx.groups[item.ParentID] = ProductGroup{ID: item.ParentID, Name: item.ParentID}
+		}
+	}
+	idx.breakCycles()
+	for id, group := range idx.groups {
+		parent := group.ParentID
+		if _, ok := idx.groups[parent]; !ok {
+			parent = ""
+		}
+		idx.children[parent] = append(idx.children[parent], id)
+	}
+	for parent := range idx.children {
+		idx.sortByName(idx.children[parent])
+	}
+	return idx
+}
+
+// breakCycles detaches the group that closes a parent_id loop, so every
+// group reaches the root of the tree. Groups are walked in ID order to keep
+// the result stable.
+func (idx *categoryIndex) breakCycles() {
+	ids := make([]string, 0, len(idx.groups))
+	for id := range idx.groups {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, start := range ids {
+		seen := map[string]bool{}
+		prev := ""
+		for id := start; id != ""; id = idx.groups[id].ParentID {
+			if _, ok := idx.groups[id]; !ok {
+				break
+			}
+			if seen[id] {
+				group := idx.groups[prev]
+				group.ParentID = ""
+				idx.groups[prev] = group
+				break
+			}
+			seen[id] = true
+			prev = id
+		}
+	}
+}
+
+// resolve finds a group by ID, exact name or a unique name fragment.
+func (idx *categoryIndex) resolve(query string) (ProductGroup, error) {
+	query = strings.TrimSpace(query)
+	if group, ok := idx.groups[query]; ok {
+		return group, nil
+	}
+	needle := strings.ToLower(query)
+	var partial []ProductGroup
+	for _, group := range idx.groups {
+		name := strings.ToLower(strings.TrimSpace(group.Name))
+		if name == needle {
+			return group, nil
+		}
+		if needle != "" && strings.Contains(name, needle) {
+			partial = append(partial, group)
+		}
+	}
+	switch len(partial) {
+	case 0:
+		return ProductGroup{}, fmt.Errorf("%w: %q", ErrCategoryNotFound, query)
+	case 1:
+		return partial[0], nil
+	default:
+		names := make([]string, 0, len(partial))
+		for _, group := range partial {
+			names = append(names, idx.path(group.ID))
+		}
+		sort.Strings(names)
+		return ProductGroup{}, fmt.Errorf("category %q is ambiguous: %s", query, strings.Join(names, "; "))
+	}
+}
+
+// lineage returns group IDs from the root down to groupID.
+func (idx *categoryIndex) lineage(groupID string) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for id := groupID; id != "" && !seen[id]; id = idx.groups[id].ParentID {
+		if _, ok := idx.groups[id]; !ok {
+			break
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	for i, j := 0, len(ids)-1; i < j; i, j = i+1, j-1 {
+		ids[i], ids[j] = ids[j], ids[i]
+	}
+	return ids
+}
+
+func (idx *categoryIndex) path(groupID string) string {
+	lineage := idx.lineage(groupID)
+	if len(lineage) == 0 {
+		return uncategorizedName
+	}
+	names := make([]string, 0, len(lineage))
+	for _, id := range lineage {
+		names = append(names, idx.groups[id].Name)
+	}
+	return strings.Join(names, " / ")
+}
+
+// contains reports whether the product sits in groupID or any of its subgroups.
+func (idx *categoryIndex) contains(groupID, productID string) bool {
+	for _, id := range idx.lineage(idx.parentOf[productID]) {
+		if id == groupID {
+			return true
+		}
+	}
+	return false
+}
+
+// childUnder returns the group one level below rootID that holds the product,
+// rootID itself for products placed directly in it, or "" for products
+// outside rootID. An empty rootID yields top-level groups.
+func (idx *categoryIndex) childUnder(rootID, productID string) string {
+	lineage := idx.lineage(idx.parentOf[productID])
+	if rootID == "" {
+		if len(lineage) == 0 {
+			return ""
+		}
+		return lineage[0]
+	}
+	for i, id := range lineage {
+		if id != rootID {
+			continue
+		}
+		if i+1 < len(lineage) {
+			return lineage[i+1]
+		}
+		return rootID
+	}
+	return ""
+}
+
+func (idx *categoryIndex) tree(items []Item) []CategoryNode {
+	direct := map[string]int{}
+	for _, item := range items {
+		if !item.Group {
+			direct[item.ParentID]++
+		}
+	}
+	var build func(id string) CategoryNode
+	build = func(id string) CategoryNode {
+		node := CategoryNode{ID: id, Name: idx.groups[id].Name, Items: direct[id]}
+		for _, child := range idx.children[id] {
+			childNode := build(child)
+			node.Items += childNode.Items
+			node.Children = append(node.Children, childNode)
+		}
+		return node
+	}
+
+	roots := make([]CategoryNode, 0, len(idx.children[""])+1)
+	for _, id := range idx.children[""] {
+		roots = append(roots, build(id))
+	}
+	if direct[""] > 0 {
+		roots = append(roots, CategoryNode{Name: uncategorizedName, Items: direct[""]})
+	}
+	return roots
+}
+
+func (idx *categoryIndex) sortByName(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := idx.groups[ids[i]], idx.groups[ids[j]]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+}
+
+// GetCategoryTree returns product groups of the store as a tree with the
+// number of products in each group, including subgroups.
+func (c *Client) GetCategoryTree(ctx context.Context, storeID *string) ([]CategoryNode, error) {
+	if !c.hasToken() {
+		return nil, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := c.catalogSnapshot(ctx, resolvedStoreID)
+	if err != nil {
+		return nil, err
+	}
+	return newCategoryIndex(snapshot).tree(snapshot.Items), nil
+}
+
+// categoryFilter loads the category index and resolves category when it is
+// set. The index is nil when neither a filter nor grouping is requested.
+func (c *Client) categoryFilter(ctx context.Context, storeID, category string, needIndex bool) (*categoryIndex, ProductGroup, error) {
+	category = strings.TrimSpace(category)
+	if category == "" && !needIndex {
+		return nil, ProductGroup{}, nil
+	}
+	snapshot, err := c.catalogSnapshot(ctx, storeID)
+	if err != nil {
+		return nil, ProductGroup{}, err
+	}
+	idx := newCategoryIndex(snapshot)
+	if category == "" {
+		return idx, ProductGroup{}, nil
+	}
+	group, err := idx.resolve(category)
+	if err != nil {
+		return nil, ProductGroup{}, err
+	}
+	return idx, group, nil
+}
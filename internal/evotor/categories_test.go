@@ -0,0 +1,141 @@
+package evotor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testCategorySnapshot has three nested levels under Продукты, a two-group
+// loop (Акции <-> Бонусы), a group that is its own parent, a group and a
+// product pointing at missing parents, a group known only from the catalog
+// and a product without a group.
+func testCategorySnapshot() catalogSnapshot {
+	return catalogSnapshot{
+		Groups: []ProductGroup{
+			{ID: "g-food", Name: "Продукты"},
+			{ID: "g-dairy", Name: "Молочные", ParentID: "g-food"},
+			{ID: "g-cheese", Name: "Сыры", ParentID: "g-dairy"},
+			{ID: "g-a", Name: "Акции", ParentID: "g-b"},
+			{ID: "g-b", Name: "Бонусы", ParentID: "g-a"},
+			{ID: "g-self", Name: "Сезонные", ParentID: "g-self"},
+			{ID: "g-lost", Name: "Потерянные", ParentID: "g-nowhere"},
+		},
+		Items: []Item{
+			{ID: "g-drinks", Name: "Напитки", Group: true},
+			{ID: "p-cheese", ParentID: "g-cheese"},
+			{ID: "p-milk", ParentID: "g-dairy"},
+			{ID: "p-bread", ParentID: "g-food"},
+			{ID: "p-promo", ParentID: "g-a"},
+			{ID: "p-juice", ParentID: "g-drinks"},
+			{ID: "p-orphan", ParentID: "g-missing"},
+			{ID: "p-loose"},
+		},
+	}
+}
+
+func TestCategoryIndexContains(t *testing.T) {
+	idx := newCategoryIndex(testCategorySnapshot())
+	tests := []struct {
+		group, product string
+		want           bool
+	}{
+		{"g-food", "p-cheese", true},
+		{"g-dairy", "p-cheese", true},
+		{"g-cheese", "p-cheese", true},
+		{"g-dairy", "p-bread", false},
+		{"g-a", "p-promo", true},
+		{"g-b", "p-promo", true},
+		{"g-missing", "p-orphan", true},
+		{"g-food", "p-loose", false},
+		{"g-food", "p-unknown", false},
+	}
+	for _, tt := range tests {
+		if got := idx.contains(tt.group, tt.product); got != tt.want {
+			t.Errorf("contains(%s, %s) = %v, want %v", tt.group, tt.product, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryIndexChildUnder(t *testing.T) {
+	idx := newCategoryIndex(testCategorySnapshot())
+	tests := []struct {
+		root, product, want string
+	}{
+		{"", "p-cheese", "g-food"},
+		{"g-food", "p-cheese", "g-dairy"},
+		{"g-dairy", "p-cheese", "g-cheese"},
+		{"g-food", "p-bread", "g-food"},
+		{"g-cheese", "p-bread", ""},
+		{"", "p-promo", "g-b"},
+		{"", "p-loose", ""},
+	}
+	for _, tt := range tests {
+		if got := idx.childUnder(tt.root, tt.product); got != tt.want {
+			t.Errorf("childUnder(%q, %s) = %q, want %q", tt.root, tt.product, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryIndexPath(t *testing.T) {
+	idx := newCategoryIndex(testCategorySnapshot())
+	tests := map[string]string{
+		"g-cheese": "Продукты / Молочные / Сыры",
+		"g-a":      "Бонусы / Акции",
+		"g-self":   "Сезонные",
+		"g-lost":   "Потерянные",
+		"g-drinks": "Напитки",
+		"":         uncategorizedName,
+	}
+	for id, want := range tests {
+		if got := idx.path(id); got != want {
+			t.Errorf("path(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestCategoryIndexTree(t *testing.T) {
+	snapshot := testCategorySnapshot()
+	got := renderTree(newCategoryIndex(snapshot).tree(snapshot.Items))
+	want := "g-missing 1; Бонусы 1 [Акции 1]; Напитки 1; Потерянные 0; " +
+		"Продукты 3 [Молочные 2 [Сыры 1]]; Сезонные 0; Без категории 1"
+	if got != want {
+		t.Errorf("tree = %s\nwant   %s", got, want)
+	}
+}
+
+func renderTree(nodes []CategoryNode) string {
+	parts := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		part := fmt.Sprintf("%s %d", node.Name, node.Items)
+		if len(node.Children) > 0 {
+			part += " [" + renderTree(node.Children) + "]"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func TestCategoryIndexResolve(t *testing.T) {
+	idx := newCategoryIndex(testCategorySnapshot())
+	for query, want := range map[string]string{
+		"g-dairy":  "g-dairy",
+		" сыры ":   "g-cheese",
+		"напит":    "g-drinks",
+		"Продукты": "g-food",
+	} {
+		group, err := idx.resolve(query)
+		if err != nil || group.ID != want {
+			t.Errorf("resolve(%q) = %s, %v; want %s", query, group.ID, err, want)
+		}
+	}
+
+	if _, err := idx.resolve("Игрушки"); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("unknown category: err = %v, want ErrCategoryNotFound", err)
+	}
+	_, err := idx.resolve("ы")
+	if err == nil || errors.Is(err, ErrCategoryNotFound) || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("ambiguous fragment: err = %v, want the ambiguity error", err)
+	}
+}
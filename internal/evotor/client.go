@@ -4,11 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
+	"maps"
+	"math"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"simple_answer_llm/internal/config"
+	"simple_answer_llm/internal/match"
 
 	"github.com/go-resty/resty/v2"
 	"go.uber.org/zap"
@@ -17,14 +22,19 @@ import (
 const (
 	defaultBaseURL = "https://api.evotor.ru"
 	apiMediaType   = "application/vnd.evotor.v2+json"
+	// fetchDaysChunk bounds how many uncached days are fetched in one go;
+	// they are held in memory until written to the document cache.
+	fetchDaysChunk = 7
 )
 
 var (
-	ErrMissingStoreID = errors.New("evotor store id is required")
-	ErrMissingToken   = errors.New("evotor token is required")
-	ErrUnauthorized   = errors.New("evotor unauthorized")
-	ErrRateLimited    = errors.New("evotor rate limited")
-	ErrEmptyQuery     = errors.New("search query is empty")
+	ErrMissingStoreID   = errors.New("evotor store id is required")
+	ErrMissingToken     = errors.New("evotor token is required")
+	ErrUnauthorized     = errors.New("evotor unauthorized")
+	ErrRateLimited      = errors.New("evotor rate limited")
+	ErrEmptyQuery       = errors.New("search query is empty")
+	ErrCategoryNotFound = errors.New("product category not found")
+	ErrInvalidCursor    = errors.New("invalid continuation cursor")
 )
 
 type APIError struct {
@@ -41,71 +51,81 @@ func (e *APIError) Error() string {
 }
 
 type Client struct {
-	http           *resty.Client
-	defaultStoreID string
-	logger         *zap.Logger
+	http             *resty.Client
+	defaultStoreID   string
+	catalog          *catalogCache
+	documents        *documentStore
+	limiter          *rateLimiter
+	retry            retryPolicy
+	fetchConcurrency int
+	timezones        *storeTimezones
+	logger           *zap.Logger
 }
 
 func NewClient(cfg config.Config, logger *zap.Logger) *Client {
+	baseURL := strings.TrimSpace(cfg.EvotorBaseURL)
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
 	httpClient := resty.New().
-		SetBaseURL(defaultBaseURL).
+		SetBaseURL(baseURL).
 		SetHeader("Accept", apiMediaType).
 		SetHeader("Content-Type", apiMediaType).
-		SetTimeout(cfg.Timeout).
-		SetRetryCount(1).
-		SetRetryWaitTime(1 * time.Second).
-		SetRetryMaxWaitTime(2 * time.Second).
-		AddRetryCondition(func(resp *resty.Response, err error) bool {
-			if err != nil {
-				return true
-			}
-			return resp != nil && resp.StatusCode() == http.StatusTooManyRequests
-		})
+		SetTimeout(cfg.Timeout)
 
 	if cfg.EvotorToken != "" {
 		httpClient.SetAuthScheme("Bearer")
 		httpClient.SetAuthToken(cfg.EvotorToken)
 	}
 
+	fetchConcurrency := cfg.FetchConcurrency
+	if fetchConcurrency <= 0 {
+		fetchConcurrency = defaultFetchConcurrency
+	}
+
 	return &Client{
-		http:           httpClient,
-		defaultStoreID: strings.TrimSpace(cfg.EvotorStoreID),
-		logger:         logger.Named("evotor"),
+		http:             httpClient,
+		defaultStoreID:   strings.TrimSpace(cfg.EvotorStoreID),
+		catalog:          newCatalogCache(strings.TrimSpace(cfg.CacheDir), cfg.CatalogTTL),
+		documents:        newDocumentStore(strings.TrimSpace(cfg.CacheDir)),
+		limiter:          newRateLimiter(cfg.EvotorRPS, cfg.EvotorBurst),
+		retry:            newRetryPolicy(cfg),
+		fetchConcurrency: fetchConcurrency,
+		timezones:        newStoreTimezones(cfg.StoreTimezone),
+		logger:           logger.Named("evotor"),
 	}
 }
 
 func (c *Client) ListStores(ctx context.Context) ([]Store, error) {
-	if !c.hasToken() {
-		return nil, ErrMissingToken
-	}
-
-	var stores []Store
-	cursor := ""
+	return collect(c.Stores(ctx))
+}
 
-	for {
-		var resp listResponse[Store]
-		query := map[string]string{}
-		if cursor != "" {
-			query["cursor"] = cursor
-		}
-		if err := c.doGet(ctx, "/stores", query, &resp); err != nil {
-			return nil, err
+// Stores streams the account's stores page by page.
+func (c *Client) Stores(ctx context.Context) iter.Seq2[Store, error] {
+	return func(yield func(Store, error) bool) {
+		if !c.hasToken() {
+			yield(Store{}, ErrMissingToken)
+			return
 		}
-		stores = append(stores, resp.Items...)
-		if resp.Paging.NextCursor == "" {
-			break
+		for store, err := range flatten(pages[Store](ctx, c, "/stores", nil, "")) {
+			if !yield(store, err) || err != nil {
+				return
+			}
 		}
-		cursor = resp.Paging.NextCursor
 	}
-
-	return stores, nil
 }
 
-func (c *Client) SearchItems(ctx context.Context, query string, limit int, storeID *string) ([]Item, error) {
+// SearchItems ranks products by how well their names match query (see
+// package match). Exact barcode, article number and code matches come first
+// with score 1; a query that looks like an EAN is looked up by code only.
+// With a category, only products of that group and its subgroups are
+// considered and query may be empty.
+func (c *Client) SearchItems(ctx context.Context, query string, limit int, storeID *string, category string) ([]ItemMatch, error) {
 	if !c.hasToken() {
 		return nil, ErrMissingToken
 	}
-	if strings.TrimSpace(query) == "" {
+	if strings.TrimSpace(query) == "" && strings.TrimSpace(category) == "" {
 		return nil, ErrEmptyQuery
 	}
 	resolvedStoreID, err := c.resolveStoreID(storeID)
@@ -113,97 +133,240 @@ func (c *Client) SearchItems(ctx context.Context, query string, limit int, store
 		return nil, err
 	}
 
-	needle := strings.ToLower(strings.TrimSpace(query))
-	fields := "id,name,price,code,barcodes,article_number,measure_name"
-	var matches []Item
+	items, err := c.catalogItems(ctx, resolvedStoreID)
+	if err != nil {
+		return nil, err
+	}
+	categories, group, err := c.categoryFilter(ctx, resolvedStoreID, category, false)
+	if err != nil {
+		return nil, err
+	}
+	inCategory := func(itemID string) bool {
+		return categories == nil || categories.contains(group.ID, itemID)
+	}
 
-	cursor := ""
-	for {
-		var resp listResponse[Item]
-		queryParams := map[string]string{
-			"fields": fields,
+	matches := []ItemMatch{}
+	exact := map[string]bool{}
+	if strings.TrimSpace(query) != "" {
+		for _, found := range lookupItems(items, query) {
+			if inCategory(found.ID) {
+				exact[found.ID] = true
+				matches = append(matches, found)
+			}
 		}
-		if cursor != "" {
-			queryParams["cursor"] = cursor
+	}
+	if LooksLikeEAN(query) {
+		return truncateMatches(matches, limit), nil
+	}
+
+	matcher := match.New(query)
+	var byName []ItemMatch
+	for _, item := range items {
+		if item.Group || exact[item.ID] || !inCategory(item.ID) {
+			continue
 		}
-		path := fmt.Sprintf("/stores/%s/products", resolvedStoreID)
-		if err := c.doGet(ctx, path, queryParams, &resp); err != nil {
-			return nil, err
+		if score := matcher.Score(item.Name); score > 0 {
+			byName = append(byName, ItemMatch{Item: item, MatchedBy: MatchedByName, Score: roundScore(score)})
 		}
+	}
+	sort.SliceStable(byName, func(i, j int) bool {
+		return byName[i].Score > byName[j].Score
+	})
 
-		for _, item := range resp.Items {
-			if strings.Contains(strings.ToLower(item.Name), needle) {
-				matches = append(matches, item)
-				if limit > 0 && len(matches) >= limit {
-					return matches[:limit], nil
-				}
-			}
-		}
+	return truncateMatches(append(matches, byName...), limit), nil
+}
 
-		if resp.Paging.NextCursor == "" {
-			break
-		}
-		cursor = resp.Paging.NextCursor
+func truncateMatches(matches []ItemMatch, limit int) []ItemMatch {
+	if limit > 0 && len(matches) > limit {
+		return matches[:limit]
 	}
+	return matches
+}
 
-	return matches, nil
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
 }
 
-func (c *Client) SearchDocuments(ctx context.Context, from, to time.Time, storeID *string, limit, offset int) ([]DocumentShort, error) {
+func (c *Client) SyncProducts(ctx context.Context, storeID *string, full bool) (SyncResult, error) {
 	if !c.hasToken() {
-		return nil, ErrMissingToken
+		return SyncResult{}, ErrMissingToken
 	}
 	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	snapshot, found, err := c.catalog.load(resolvedStoreID)
+	if err != nil {
+		c.logger.Warn("catalog cache unreadable, running full sync", zap.String("store_id", resolvedStoreID), zap.Error(err))
+		found = false
+	}
+	return c.syncCatalog(ctx, resolvedStoreID, snapshot, full || !found)
+}
+
+func (c *Client) catalogItems(ctx context.Context, storeID string) ([]Item, error) {
+	snapshot, err := c.catalogSnapshot(ctx, storeID)
 	if err != nil {
 		return nil, err
 	}
+	return snapshot.Items, nil
+}
 
-	cursor := ""
-	var documents []DocumentShort
-	seen := 0
-	firstPage := true
+func (c *Client) catalogSnapshot(ctx context.Context, storeID string) (catalogSnapshot, error) {
+	return c.catalogSnapshotWithin(ctx, storeID, c.catalog.ttl)
+}
 
-	for {
-		var resp listResponse[DocumentShort]
-		queryParams := map[string]string{}
-		if firstPage {
-			if !from.IsZero() {
-				queryParams["since"] = fmt.Sprintf("%d", from.UnixMilli())
-			}
-			if !to.IsZero() {
-				queryParams["until"] = fmt.Sprintf("%d", to.UnixMilli())
-			}
-			firstPage = false
+// catalogSnapshotWithin syncs the catalog when the local copy is older than maxAge.
+func (c *Client) catalogSnapshotWithin(ctx context.Context, storeID string, maxAge time.Duration) (catalogSnapshot, error) {
+	snapshot, found, err := c.catalog.load(storeID)
+	if err != nil {
+		c.logger.Warn("catalog cache unreadable, running full sync", zap.String("store_id", storeID), zap.Error(err))
+		found = false
+	}
+	if found && !snapshot.stale(maxAge, time.Now()) {
+		return snapshot, nil
+	}
+
+	if _, err := c.syncCatalog(ctx, storeID, snapshot, !found); err != nil {
+		return catalogSnapshot{}, err
+	}
+	snapshot, _, err = c.catalog.load(storeID)
+	if err != nil {
+		return catalogSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+func (c *Client) syncCatalog(ctx context.Context, storeID string, snapshot catalogSnapshot, full bool) (SyncResult, error) {
+	startedAt := time.Now()
+	full = full || snapshot.fullSyncDue(startedAt)
+	var since time.Time
+	if !full {
+		since = snapshot.SyncedAt.Add(-catalogSyncOverlap)
+	}
+
+	updated, err := c.fetchProducts(ctx, storeID, since)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	// Groups are few and have no reliable update time, so they are always
+	// re-read in full. A failure keeps the previously known tree.
+	groups, groupsErr := c.fetchProductGroups(ctx, storeID)
+	if groupsErr != nil {
+		c.logger.Warn("product groups unavailable", zap.String("store_id", storeID), zap.Error(groupsErr))
+		groups = snapshot.Groups
+	}
+
+	if full {
+		snapshot = catalogSnapshot{Items: updated, FullSyncedAt: startedAt}
+	} else {
+		snapshot = snapshot.merge(updated)
+	}
+	snapshot.Groups = groups
+	snapshot.StoreID = storeID
+	snapshot.SyncedAt = startedAt
+	if err := c.catalog.save(snapshot); err != nil {
+		return SyncResult{}, err
+	}
+
+	c.logger.Info("catalog synced",
+		zap.String("store_id", storeID),
+		zap.Bool("full", full),
+		zap.Int("fetched", len(updated)),
+		zap.Int("total", len(snapshot.Items)),
+		zap.Int("groups", len(snapshot.Groups)),
+	)
+
+	return SyncResult{
+		StoreID:  storeID,
+		Full:     full,
+		Fetched:  len(updated),
+		Total:    len(snapshot.Items),
+		Groups:   len(snapshot.Groups),
+		SyncedAt: startedAt,
+	}, nil
+}
+
+func (c *Client) fetchProducts(ctx context.Context, storeID string, since time.Time) ([]Item, error) {
+	query := map[string]string{}
+	if !since.IsZero() {
+		query["since"] = fmt.Sprintf("%d", since.UnixMilli())
+	}
+	return listAll[Item](ctx, c, fmt.Sprintf("/stores/%s/products", storeID), query)
+}
+
+func (c *Client) fetchProductGroups(ctx context.Context, storeID string) ([]ProductGroup, error) {
+	return listAll[ProductGroup](ctx, c, fmt.Sprintf("/stores/%s/product-groups", storeID), nil)
+}
+
+// SearchDocuments returns up to limit documents of a period. When more
+// remain, NextCursor continues right after the last returned document; pass
+// it back as cursor to get the next page without scanning earlier ones. A
+// cursor carries its own period and store, so from, to and storeID are
+// ignored with it.
+func (c *Client) SearchDocuments(ctx context.Context, from, to time.Time, storeID *string, limit int, cursor string) (DocumentPage, error) {
+	if !c.hasToken() {
+		return DocumentPage{}, ErrMissingToken
+	}
+	var resolvedStoreID string
+	var start documentPosition
+	if strings.TrimSpace(cursor) != "" {
+		state, err := decodeDocumentCursor(cursor)
+		if err != nil {
+			return DocumentPage{}, err
 		}
-		if cursor != "" {
-			queryParams["cursor"] = cursor
+		resolvedStoreID, from, to, start = state.StoreID, state.From, state.To, state.Position
+	} else {
+		var err error
+		resolvedStoreID, err = c.resolveStoreID(storeID)
+		if err != nil {
+			return DocumentPage{}, err
 		}
+	}
 
-		path := fmt.Sprintf("/stores/%s/documents", resolvedStoreID)
-		if err := c.doGet(ctx, path, queryParams, &resp); err != nil {
-			return nil, err
+	result := DocumentPage{Documents: []DocumentShort{}}
+	var last documentPosition
+	for doc, err := range c.documentStream(ctx, resolvedStoreID, from, to, start) {
+		if err != nil {
+			return DocumentPage{}, err
 		}
+		if limit > 0 && len(result.Documents) == limit {
+			// There is at least one more document: resume after the last one
+			// returned.
+			result.NextCursor = documentCursor{StoreID: resolvedStoreID, From: from, To: to, Position: last}.encode()
+			break
+		}
+		result.Documents = append(result.Documents, doc.Short())
+		last = doc.next
+	}
+	return result, nil
+}
 
-		for _, doc := range resp.Items {
-			doc.Total = pickDocumentTotal(doc.Body)
-			if seen < offset {
-				seen++
-				continue
+// Documents streams documents of a period in close date order, requesting
+// Evotor pages only as the loop advances. Breaking out of the loop or
+// cancelling ctx stops further requests.
+func (c *Client) Documents(ctx context.Context, from, to time.Time, storeID *string) iter.Seq2[DocumentShort, error] {
+	return func(yield func(DocumentShort, error) bool) {
+		if !c.hasToken() {
+			yield(DocumentShort{}, ErrMissingToken)
+			return
+		}
+		resolvedStoreID, err := c.resolveStoreID(storeID)
+		if err != nil {
+			yield(DocumentShort{}, err)
+			return
+		}
+		for doc, err := range c.documentsSeq(ctx, resolvedStoreID, from, to) {
+			if err != nil {
+				yield(DocumentShort{}, err)
+				return
 			}
-			documents = append(documents, doc)
-			seen++
-			if limit > 0 && len(documents) >= limit {
-				return documents[:limit], nil
+			if !yield(doc.Short(), nil) {
+				return
 			}
 		}
-
-		if resp.Paging.NextCursor == "" {
-			break
-		}
-		cursor = resp.Paging.NextCursor
 	}
-
-	return documents, nil
 }
 
 func (c *Client) GetDocument(ctx context.Context, docID string, storeID *string) (DocumentFull, error) {
@@ -217,17 +380,26 @@ func (c *Client) GetDocument(ctx context.Context, docID string, storeID *string)
 	if err != nil {
 		return DocumentFull{}, err
 	}
+	return c.getDocument(ctx, resolvedStoreID, docID)
+}
+
+func (c *Client) getDocument(ctx context.Context, storeID, docID string) (DocumentFull, error) {
+	if cached, ok := c.documents.lookup(storeID, docID); ok {
+		return cached, nil
+	}
 
 	var resp DocumentFull
-	path := fmt.Sprintf("/stores/%s/documents/%s", resolvedStoreID, docID)
+	path := fmt.Sprintf("/stores/%s/documents/%s", storeID, docID)
 	if err := c.doGet(ctx, path, nil, &resp); err != nil {
 		return DocumentFull{}, err
 	}
-	resp.Total = pickDocumentTotal(resp.Body)
 	return resp, nil
 }
 
-func (c *Client) GetSalesMetrics(ctx context.Context, from, to time.Time, storeID *string, documentType *string) (SalesMetrics, error) {
+// GetSalesMetrics counts documents of a period and their total. Like every
+// report it counts only SELL for an empty documentType; pass DocumentTypeAll
+// to count every type.
+func (c *Client) GetSalesMetrics(ctx context.Context, from, to time.Time, storeID *string, documentType DocumentType) (SalesMetrics, error) {
 	if !c.hasToken() {
 		return SalesMetrics{}, ErrMissingToken
 	}
@@ -235,98 +407,360 @@ func (c *Client) GetSalesMetrics(ctx context.Context, from, to time.Time, storeI
 	if err != nil {
 		return SalesMetrics{}, err
 	}
+	metrics, _, err := c.salesMetrics(ctx, resolvedStoreID, from, to, documentType)
+	return metrics, err
+}
 
-	var allDocuments []DocumentShort
-	cursor := ""
-	firstPage := true
-
-	for {
-		var resp listResponse[DocumentShort]
-		queryParams := map[string]string{}
-		if firstPage {
-			if !from.IsZero() {
-				queryParams["since"] = fmt.Sprintf("%d", from.UnixMilli())
-			}
-			if !to.IsZero() {
-				queryParams["until"] = fmt.Sprintf("%d", to.UnixMilli())
-			}
-			firstPage = false
+// salesMetrics also returns the receipt sample behind the receipt stats so
+// callers can combine several stores.
+func (c *Client) salesMetrics(ctx context.Context, storeID string, from, to time.Time, documentType DocumentType) (SalesMetrics, receiptSample, error) {
+	count := 0
+	var totalSum Money
+	docTypes := map[DocumentType]int{}
+	var receipts receiptSample
+	singleType := documentType != DocumentTypeAll
+
+	for doc, err := range c.documentsSeq(ctx, storeID, from, to) {
+		if err != nil {
+			return SalesMetrics{}, receiptSample{}, err
 		}
-		if cursor != "" {
-			queryParams["cursor"] = cursor
+		total := pickDocumentTotal(doc.Body)
+		if doc.Type == "" {
+			doc.Type = DocumentUnknown
 		}
 
-		path := fmt.Sprintf("/stores/%s/documents", resolvedStoreID)
-		if err := c.doGet(ctx, path, queryParams, &resp); err != nil {
-			return SalesMetrics{}, err
+		if !documentType.matches(doc.Type) {
+			continue
 		}
 
-		allDocuments = append(allDocuments, resp.Items...)
+		count++
+		totalSum += total
+		docTypes[doc.Type]++
+		// Mixing returns into the check distribution makes it meaningless,
+		// so receipt stats cover sales unless one type was asked for.
+		if singleType || doc.Type.IsSale() {
+			receipts.add(doc)
+		}
+	}
 
-		if resp.Paging.NextCursor == "" {
-			break
+	fromStr, toStr := periodBounds(from, to)
+
+	return SalesMetrics{
+		Count:         count,
+		TotalSum:      totalSum,
+		StoreID:       storeID,
+		From:          fromStr,
+		To:            toStr,
+		DocumentTypes: docTypes,
+		ReceiptStats:  receipts.stats(),
+	}, receipts, nil
+}
+
+// documentsSeq streams the documents of a period; see documentStream.
+func (c *Client) documentsSeq(ctx context.Context, storeID string, from, to time.Time) iter.Seq2[DocumentFull, error] {
+	return func(yield func(DocumentFull, error) bool) {
+		for doc, err := range c.documentStream(ctx, storeID, from, to, documentPosition{}) {
+			if !yield(doc.DocumentFull, err) || err != nil {
+				return
+			}
 		}
-		cursor = resp.Paging.NextCursor
 	}
+}
 
-	count := 0
-	var totalSum float64
-	docTypes := map[string]int{}
+// documentStream answers closed UTC days from the local document store and
+// requests only uncached days and the open tail of the range from Evotor.
+// Uncached days are fetched at most fetchDaysChunk at a time, so only that
+// many days of documents are held at once. Every document carries the
+// position right after it, and the stream can start from such a position.
+// Raw is dropped from streamed documents: only the cache and GetDocument
+// need the original JSON.
+func (c *Client) documentStream(ctx context.Context, storeID string, from, to time.Time, start documentPosition) iter.Seq2[positionedDocument, error] {
+	return func(yield func(positionedDocument, error) bool) {
+		// remote streams Evotor pages from cursor, skipping the first skip
+		// documents of the first page. day is where the open tail begins.
+		remote := func(day time.Time, cursor string, skip int) {
+			since := from
+			if day.After(since) {
+				since = day
+			}
+			for page, err := range c.fetchDocumentPages(ctx, storeID, since, to, cursor) {
+				if err != nil {
+					yield(positionedDocument{}, err)
+					return
+				}
+				for i, doc := range page.items[min(skip, len(page.items)):] {
+					doc.Raw = nil
+					next := documentPosition{Day: day, Remote: true, Page: page.cursor, Offset: skip + i + 1}
+					if !yield(positionedDocument{DocumentFull: doc, next: next}, nil) {
+						return
+					}
+				}
+				skip = 0
+			}
+		}
 
-	for _, doc := range allDocuments {
-		total := pickDocumentTotal(doc.Body)
-		doc.Type = strings.TrimSpace(doc.Type)
-		if doc.Type == "" {
-			doc.Type = "UNKNOWN"
+		now := time.Now()
+		if from.IsZero() {
+			remote(time.Time{}, start.Page, start.Offset)
+			return
+		}
+		if to.IsZero() || to.After(now) {
+			to = now
+		}
+		if start.Remote {
+			remote(start.Day, start.Page, start.Offset)
+			return
 		}
 
-		if documentType != nil {
-			docTypeUpper := strings.ToUpper(*documentType)
-			if docTypeUpper != "ALL" {
-				if strings.ToUpper(doc.Type) != docTypeUpper {
+		// local streams in-range documents of a closed day, skipping those
+		// before the start position.
+		local := func(day time.Time, docs []DocumentFull) bool {
+			skip := 0
+			if day.Equal(start.Day) {
+				skip = start.Offset
+			}
+			n := 0
+			for _, doc := range docs {
+				inRange, err := documentInRange(doc, from, to)
+				if err != nil {
+					c.logger.Warn("skipping cached document with an unreadable close date", zap.String("store_id", storeID), zap.Error(err))
+				}
+				if !inRange {
+					continue
+				}
+				n++
+				if n <= skip {
 					continue
 				}
+				doc.Raw = nil
+				if !yield(positionedDocument{DocumentFull: doc, next: documentPosition{Day: day, Offset: n}}, nil) {
+					return false
+				}
 			}
+			return true
 		}
 
-		count++
-		totalSum += total
-		docTypes[doc.Type]++
+		var pending []time.Time
+		flush := func() bool {
+			if len(pending) == 0 {
+				return true
+			}
+			first, last := pending[0], pending[len(pending)-1]
+			pending = nil
+
+			fetched, err := collect(flatten(c.fetchDocumentPages(ctx, storeID, first, last.AddDate(0, 0, 1).Add(-time.Millisecond), "")))
+			if err != nil {
+				yield(positionedDocument{}, err)
+				return false
+			}
+			byDay, err := documentsByDay(fetched, first)
+			if err == nil {
+				err = c.storeDocumentDays(storeID, first, last, byDay)
+			}
+			if err != nil {
+				c.logger.Warn("document cache write failed", zap.String("store_id", storeID), zap.Error(err))
+			}
+			for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+				if !local(day, byDay[day]) {
+					return false
+				}
+			}
+			return true
+		}
+
+		day := utcDay(from)
+		if start.Day.After(day) {
+			day = start.Day
+		}
+		for ; !day.After(to); day = day.AddDate(0, 0, 1) {
+			if err := ctx.Err(); err != nil {
+				yield(positionedDocument{}, err)
+				return
+			}
+			if !dayClosed(day, now) {
+				if flush() {
+					remote(day, "", 0)
+				}
+				return
+			}
+
+			cached, found, err := c.documents.load(storeID, day)
+			if err != nil {
+				c.logger.Warn("document cache unreadable", zap.String("store_id", storeID), zap.Time("day", day), zap.Error(err))
+			}
+			if !found {
+				pending = append(pending, day)
+				if len(pending) == fetchDaysChunk && !flush() {
+					return
+				}
+				continue
+			}
+			if !flush() || !local(day, cached) {
+				return
+			}
+		}
+		flush()
+	}
+}
+
+// documentsByDay groups documents by UTC close day. Documents with an
+// unreadable close date go to fallback and are reported as an error.
+func documentsByDay(documents []DocumentFull, fallback time.Time) (map[time.Time][]DocumentFull, error) {
+	byDay := map[time.Time][]DocumentFull{}
+	var firstErr error
+	for _, doc := range documents {
+		closed, err := ParseTime(doc.CloseDate)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("document %s: %w", doc.ID, err)
+			}
+			byDay[fallback] = append(byDay[fallback], doc)
+			continue
+		}
+		day := utcDay(closed)
+		byDay[day] = append(byDay[day], doc)
 	}
+	return byDay, firstErr
+}
 
-	fromStr := ""
-	toStr := ""
+func (c *Client) storeDocumentDays(storeID string, first, last time.Time, byDay map[time.Time][]DocumentFull) error {
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if err := c.documents.save(storeID, day, byDay[day]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchDocumentPages requests documents from Evotor starting at cursor, or at
+// the first page of the period when cursor is empty.
+func (c *Client) fetchDocumentPages(ctx context.Context, storeID string, from, to time.Time, cursor string) iter.Seq2[page[DocumentFull], error] {
+	query := map[string]string{}
 	if !from.IsZero() {
-		fromStr = from.Format(time.RFC3339)
+		query["since"] = fmt.Sprintf("%d", from.UnixMilli())
 	}
 	if !to.IsZero() {
-		toStr = to.Format(time.RFC3339)
+		query["until"] = fmt.Sprintf("%d", to.UnixMilli())
 	}
-
-	return SalesMetrics{
-		Count:         count,
-		TotalSum:      totalSum,
-		StoreID:       resolvedStoreID,
-		From:          fromStr,
-		To:            toStr,
-		DocumentTypes: docTypes,
-	}, nil
+	return pages[DocumentFull](ctx, c, fmt.Sprintf("/stores/%s/documents", storeID), query, cursor)
 }
 
 func (c *Client) doGet(ctx context.Context, path string, query map[string]string, result any) error {
-	req := c.http.R().SetContext(ctx).SetResult(result)
-	if len(query) > 0 {
-		req.SetQueryParams(query)
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return fmt.Errorf("evotor request: %w", err)
+		}
+
+		req := c.http.R().SetContext(ctx).SetResult(result)
+		if len(query) > 0 {
+			req.SetQueryParams(query)
+		}
+		resp, err := req.Get(path)
+
+		if err == nil && !resp.IsError() {
+			return nil
+		}
+		if attempt >= c.retry.maxAttempts || !c.retry.retryable(ctx, resp, err) {
+			if err != nil {
+				return fmt.Errorf("evotor request: %w", err)
+			}
+			return apiErrorFromResponse(resp)
+		}
+
+		now := time.Now()
+		delay, ok := c.retry.delay(attempt, resp, now)
+		if deadline, set := ctx.Deadline(); set && now.Add(delay).After(deadline) {
+			// The retry could not finish in time; report why the last
+			// attempt failed rather than the deadline.
+			ok = false
+		}
+		if !ok {
+			if err != nil {
+				return fmt.Errorf("evotor request: %w", err)
+			}
+			return apiErrorFromResponse(resp)
+		}
+		fields := []zap.Field{
+			zap.String("path", path),
+			zap.Int("attempt", attempt),
+			zap.Duration("delay", delay),
+		}
+		if err != nil {
+			fields = append(fields, zap.Error(err))
+		} else {
+			fields = append(fields, zap.Int("status", resp.StatusCode()))
+		}
+		c.logger.Debug("evotor request retry", fields...)
+
+		if err := sleepContext(ctx, delay); err != nil {
+			return fmt.Errorf("evotor request: %w", err)
+		}
 	}
+}
 
-	resp, err := req.Get(path)
-	if err != nil {
-		return fmt.Errorf("evotor request: %w", err)
+func listAll[T any](ctx context.Context, c *Client, path string, query map[string]string) ([]T, error) {
+	return collect(flatten(pages[T](ctx, c, path, query, "")))
+}
+
+// page is one response of a cursor-paginated list with the cursor that
+// requested it; the first page has an empty cursor.
+type page[T any] struct {
+	items  []T
+	cursor string
+}
+
+// pages streams a cursor-paginated list one page at a time, starting at
+// cursor. query applies to the first request only; later requests carry
+// just the cursor.
+func pages[T any](ctx context.Context, c *Client, path string, query map[string]string, cursor string) iter.Seq2[page[T], error] {
+	return func(yield func(page[T], error) bool) {
+		for {
+			queryParams := map[string]string{}
+			if cursor != "" {
+				queryParams["cursor"] = cursor
+			} else {
+				maps.Copy(queryParams, query)
+			}
+			var resp listResponse[T]
+			if err := c.doGet(ctx, path, queryParams, &resp); err != nil {
+				yield(page[T]{}, err)
+				return
+			}
+			if !yield(page[T]{items: resp.Items, cursor: cursor}, nil) || resp.Paging.NextCursor == "" {
+				return
+			}
+			cursor = resp.Paging.NextCursor
+		}
 	}
-	if resp.IsError() {
-		return apiErrorFromResponse(resp)
+}
+
+func flatten[T any](seq iter.Seq2[page[T], error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p, err := range seq {
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range p.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
 	}
-	return nil
+}
+
+// collect drains seq, stopping at the first error.
+func collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
 }
 
 func (c *Client) resolveStoreID(storeID *string) (string, error) {
@@ -363,7 +797,11 @@ func apiErrorFromResponse(resp *resty.Response) error {
 	}
 }
 
-func pickDocumentTotal(body DocumentBody) float64 {
+// pickDocumentTotal prefers the amount after discounts.
+func pickDocumentTotal(body DocumentBody) Money {
+	if body.ResultSum != 0 {
+		return body.ResultSum
+	}
 	if body.Total != 0 {
 		return body.Total
 	}
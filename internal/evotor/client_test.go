@@ -0,0 +1,384 @@
+package evotor_test
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"simple_answer_llm/internal/config"
+	"simple_answer_llm/internal/evotor"
+	"simple_answer_llm/internal/evotor/evotortest"
+
+	"go.uber.org/zap"
+)
+
+const (
+	testStoreID    = "20240101-0000-4000-8000-000000000001"
+	testOtherStore = "20240101-0000-4000-8000-000000000002"
+)
+
+// newTestClient starts a fake Evotor API with the default fixtures and
+// returns a client pointed at it. cfg may adjust the client config.
+func newTestClient(t *testing.T, opts evotortest.Options, cfg func(*config.Config)) (*evotor.Client, *evotortest.Server) {
+	t.Helper()
+	fixtures, err := evotortest.DefaultFixtures()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newFixturesClient(t, fixtures, opts, cfg)
+}
+
+func newFixturesClient(t *testing.T, fixtures *evotortest.Fixtures, opts evotortest.Options, cfg func(*config.Config)) (*evotor.Client, *evotortest.Server) {
+	t.Helper()
+	srv, fake := evotortest.NewTestServer(fixtures, opts)
+	t.Cleanup(srv.Close)
+
+	c := config.Config{
+		EvotorToken:      "test-token",
+		EvotorStoreID:    testStoreID,
+		EvotorBaseURL:    srv.URL,
+		Timeout:          5 * time.Second,
+		CatalogTTL:       time.Hour,
+		RetryMaxAttempts: 3,
+		RetryBaseDelay:   time.Millisecond,
+		RetryMaxDelay:    5 * time.Millisecond,
+	}
+	if cfg != nil {
+		cfg(&c)
+	}
+	return evotor.NewClient(c, zap.NewNop()), fake
+}
+
+// testGroups and testProducts are a small catalog for newStoreClient:
+// Продукты > Молочные and Косметика > Уход за волосами.
+const (
+	testGroups = `[
+{"id": "g-food", "name": "Продукты", "group": true},
+{"id": "g-dairy", "name": "Молочные", "parent_id": "g-food", "group": true},
+{"id": "g-cosmetics", "name": "Косметика", "group": true},
+{"id": "g-hair", "name": "Уход за волосами", "parent_id": "g-cosmetics", "group": true}
+]`
+	testProducts = `[
+{"id": "p-milk", "name": "Молоко 3,2%", "parent_id": "g-dairy", "price": 100, "cost_price": 70, "quantity": 10, "measure_name": "шт"},
+{"id": "p-bread", "name": "Хлеб", "parent_id": "g-food", "price": 50, "cost_price": 30, "quantity": 0, "measure_name": "шт"},
+{"id": "p-lak", "name": "Лак для волос", "parent_id": "g-hair", "price": 250, "cost_price": 150, "quantity": -2, "measure_name": "шт"}
+]`
+)
+
+// newStoreClient serves files, laid out as for evotortest.LoadFixtures, for
+// a single store "s1" that is the client's default store. The store works in
+// UTC unless cfg sets another timezone.
+func newStoreClient(t *testing.T, files map[string]string, cfg func(*config.Config)) (*evotor.Client, *evotortest.Server) {
+	t.Helper()
+	fsys := fstest.MapFS{"stores.json": {Data: []byte(`[{"id": "s1", "name": "Тестовый"}]`)}}
+	for name, data := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(data)}
+	}
+	fixtures, err := evotortest.LoadFixtures(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newFixturesClient(t, fixtures, evotortest.Options{}, func(c *config.Config) {
+		c.EvotorStoreID = "s1"
+		c.StoreTimezone = "UTC"
+		if cfg != nil {
+			cfg(c)
+		}
+	})
+}
+
+func TestListStoresFollowsCursor(t *testing.T) {
+	client, fake := newTestClient(t, evotortest.Options{PageSize: 1}, nil)
+
+	stores, err := client.ListStores(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stores) != 2 || stores[0].ID != testStoreID || stores[1].ID != testOtherStore {
+		t.Fatalf("stores = %+v, want both fixture stores in order", stores)
+	}
+	if got := fake.Requests(); got != 2 {
+		t.Errorf("requests = %d, want one per page (2)", got)
+	}
+}
+
+func TestDocumentsPagingMatchesSingleRequest(t *testing.T) {
+	ctx := context.Background()
+	paged, _ := newTestClient(t, evotortest.Options{PageSize: 7}, nil)
+	whole, _ := newTestClient(t, evotortest.Options{PageSize: 1000}, nil)
+
+	a := documentIDs(t, paged.Documents(ctx, time.Time{}, time.Time{}, nil))
+	b := documentIDs(t, whole.Documents(ctx, time.Time{}, time.Time{}, nil))
+	if len(a) == 0 || len(a) != len(b) {
+		t.Fatalf("paged %d documents, single request %d", len(a), len(b))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("document %d: paged %s, single request %s", i, a[i], b[i])
+		}
+	}
+}
+
+func TestDocumentsSinceUntil(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t, evotortest.Options{PageSize: 10}, nil)
+	from := time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+
+	var want []string
+	for doc, err := range client.Documents(ctx, time.Time{}, time.Time{}, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		closed, err := evotor.ParseTime(doc.CloseDate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closed.Before(from) && !closed.After(to) {
+			want = append(want, doc.ID)
+		}
+	}
+
+	got := documentIDs(t, client.Documents(ctx, from, to, nil))
+	if len(want) == 0 || len(got) != len(want) {
+		t.Fatalf("got %d documents in period, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("document %d: got %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSearchDocumentsResumesFromCursor(t *testing.T) {
+	ctx := context.Background()
+	for _, cacheDir := range []string{"", t.TempDir()} {
+		client, _ := newTestClient(t, evotortest.Options{PageSize: 5}, func(cfg *config.Config) {
+			cfg.CacheDir = cacheDir
+		})
+		want := documentIDs(t, client.Documents(ctx, time.Time{}, time.Time{}, nil))
+
+		var got []string
+		cursor := ""
+		for pages := 0; ; pages++ {
+			if pages > len(want) {
+				t.Fatalf("cache dir %q: cursor does not advance", cacheDir)
+			}
+			page, err := client.SearchDocuments(ctx, time.Time{}, time.Time{}, nil, 7, cursor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, doc := range page.Documents {
+				got = append(got, doc.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+
+		if len(want) == 0 || len(got) != len(want) {
+			t.Fatalf("cache dir %q: pages hold %d documents, want %d", cacheDir, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("cache dir %q: document %d: got %s, want %s", cacheDir, i, got[i], want[i])
+			}
+		}
+	}
+
+	client, _ := newTestClient(t, evotortest.Options{}, nil)
+	if _, err := client.SearchDocuments(ctx, time.Time{}, time.Time{}, nil, 7, "garbage!"); !errors.Is(err, evotor.ErrInvalidCursor) {
+		t.Errorf("err = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestGetDocumentKeepsRawFromCache(t *testing.T) {
+	ctx := context.Background()
+	for _, cacheDir := range []string{"", t.TempDir()} {
+		client, fake := newTestClient(t, evotortest.Options{}, func(cfg *config.Config) {
+			cfg.CacheDir = cacheDir
+		})
+		from := time.Date(2025, 12, 7, 0, 0, 0, 0, time.UTC)
+		if ids := documentIDs(t, client.Documents(ctx, from, from.AddDate(0, 0, 1), nil)); len(ids) == 0 {
+			t.Fatal("no documents on 2025-12-07")
+		}
+		requests := fake.Requests()
+
+		doc, err := client.GetDocument(ctx, "d0000000-0000-4000-8000-000000000010", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fake.Requests(); got != requests {
+			t.Errorf("cache dir %q: GetDocument made %d requests, want the cached day", cacheDir, got-requests)
+		}
+		if phone, ok, err := doc.Field("extras.customer_phone"); err != nil || !ok || phone != "+79147402509" {
+			t.Errorf("cache dir %q: extras.customer_phone = %v, %v, %v, want the raw field", cacheDir, phone, ok, err)
+		}
+	}
+}
+
+func TestGetSalesMetricsDocumentTypeDefault(t *testing.T) {
+	documents := `[
+{"id": "sell", "type": "SELL", "close_date": "2025-12-01T10:00:00.000+0000", "body": {"result_sum": 100}},
+{"id": "payback", "type": "PAYBACK", "close_date": "2025-12-01T11:00:00.000+0000", "body": {"result_sum": 30}},
+{"id": "cash", "type": "CASH_INCOME", "close_date": "2025-12-01T12:00:00.000+0000", "body": {"sum": 500}}
+]`
+	client, _ := newStoreClient(t, map[string]string{"stores/s1/documents.json": documents}, nil)
+	ctx := context.Background()
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 1, 23, 59, 59, 0, time.UTC)
+
+	sales, err := client.GetSalesMetrics(ctx, from, to, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sales.Count != 1 || sales.TotalSum != 10000 {
+		t.Errorf("empty type: %d documents, %s; want only the SELL", sales.Count, sales.TotalSum)
+	}
+
+	all, err := client.GetSalesMetrics(ctx, from, to, nil, evotor.DocumentTypeAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all.Count != 3 || all.DocumentTypes[evotor.DocumentPayback] != 1 || all.DocumentTypes[evotor.DocumentCashIncome] != 1 {
+		t.Errorf("ALL: %d documents, types %v; want every document", all.Count, all.DocumentTypes)
+	}
+}
+
+func TestSalesMetricsTotalAfterDiscounts(t *testing.T) {
+	// total_sum takes result_sum first, then total, then sum.
+	documents := `[
+{"id": "base", "type": "SELL", "close_date": "2025-12-01T10:00:00.000+0000", "body": {"sum": 200, "total": 200, "result_sum": 120}},
+{"id": "discounted", "type": "SELL", "close_date": "2025-12-02T10:00:00.000+0000", "body": {"sum": 100, "total": 100, "result_sum": 90}},
+{"id": "total-only", "type": "SELL", "close_date": "2025-12-02T11:00:00.000+0000", "body": {"sum": 50, "total": 40}},
+{"id": "sum-only", "type": "SELL", "close_date": "2025-12-02T12:00:00.000+0000", "body": {"sum": 20}}
+]`
+	client, _ := newStoreClient(t, map[string]string{"stores/s1/documents.json": documents}, nil)
+	ctx := context.Background()
+	day := func(d int) (time.Time, time.Time) {
+		from := time.Date(2025, 12, d, 0, 0, 0, 0, time.UTC)
+		return from, from.Add(24*time.Hour - time.Second)
+	}
+	from, to := day(2)
+	baseFrom, baseTo := day(1)
+
+	sales, err := client.GetSalesMetrics(ctx, from, to, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sales.TotalSum != 15000 {
+		t.Errorf("total_sum = %s, want 150.00", sales.TotalSum)
+	}
+
+	comparison, err := client.CompareSalesMetrics(ctx, from, to, baseFrom, baseTo, nil, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := comparison.Changes.TotalSum; got.Current != 15000 || got.Previous != 12000 || got.Delta != 3000 {
+		t.Errorf("total_sum change = %+v, want 150.00 against 120.00", got)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("401", func(t *testing.T) {
+		client, _ := newTestClient(t, evotortest.Options{Token: "other"}, nil)
+		if _, err := client.ListStores(ctx); !errors.Is(err, evotor.ErrUnauthorized) {
+			t.Fatalf("err = %v, want ErrUnauthorized", err)
+		}
+	})
+
+	t.Run("403", func(t *testing.T) {
+		client, _ := newTestClient(t, evotortest.Options{ForbiddenStores: []string{testStoreID}}, nil)
+		_, err := client.GetDocument(ctx, "d0000000-0000-4000-8000-000000000002", nil)
+		if !errors.Is(err, evotor.ErrUnauthorized) {
+			t.Fatalf("err = %v, want ErrUnauthorized", err)
+		}
+	})
+
+	t.Run("5xx retried", func(t *testing.T) {
+		client, fake := newTestClient(t, evotortest.Options{}, nil)
+		fake.InjectError(http.StatusServiceUnavailable, 2)
+		stores, err := client.ListStores(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stores) != 2 || fake.Requests() != 3 {
+			t.Fatalf("stores = %d, requests = %d, want 2 stores after 3 requests", len(stores), fake.Requests())
+		}
+	})
+
+	t.Run("429 Retry-After over max delay honored", func(t *testing.T) {
+		// Retry-After (1s) is far above RetryMaxDelay (5ms) but within
+		// RetryAfterMax.
+		client, fake := newTestClient(t, evotortest.Options{RetryAfter: time.Second}, nil)
+		fake.InjectError(http.StatusTooManyRequests, 1)
+		started := time.Now()
+		if _, err := client.ListStores(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if elapsed := time.Since(started); elapsed < time.Second {
+			t.Errorf("retried after %v, want Retry-After (1s) honored", elapsed)
+		}
+		if got := fake.Requests(); got != 2 {
+			t.Errorf("requests = %d, want 2", got)
+		}
+	})
+
+	t.Run("429 Retry-After over RetryAfterMax", func(t *testing.T) {
+		client, fake := newTestClient(t, evotortest.Options{RetryAfter: time.Hour}, func(cfg *config.Config) {
+			cfg.RetryAfterMax = time.Minute
+		})
+		fake.InjectError(http.StatusTooManyRequests, 1)
+		if _, err := client.ListStores(ctx); !errors.Is(err, evotor.ErrRateLimited) {
+			t.Fatalf("err = %v, want ErrRateLimited", err)
+		}
+		if got := fake.Requests(); got != 1 {
+			t.Errorf("requests = %d, want no retry (1)", got)
+		}
+	})
+
+	t.Run("429 Retry-After past the deadline", func(t *testing.T) {
+		client, fake := newTestClient(t, evotortest.Options{RetryAfter: 30 * time.Second}, nil)
+		fake.InjectError(http.StatusTooManyRequests, 1)
+		deadlineCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		started := time.Now()
+		if _, err := client.ListStores(deadlineCtx); !errors.Is(err, evotor.ErrRateLimited) {
+			t.Fatalf("err = %v, want ErrRateLimited", err)
+		}
+		if elapsed := time.Since(started); elapsed > time.Second {
+			t.Errorf("gave up after %v, want at once", elapsed)
+		}
+	})
+
+	t.Run("5xx exhausted", func(t *testing.T) {
+		client, fake := newTestClient(t, evotortest.Options{}, nil)
+		fake.InjectError(http.StatusBadGateway, 10)
+		var apiErr *evotor.APIError
+		if _, err := client.ListStores(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadGateway {
+			t.Fatalf("err = %v, want 502 APIError", err)
+		}
+		if got := fake.Requests(); got != 3 {
+			t.Errorf("requests = %d, want RetryMaxAttempts (3)", got)
+		}
+	})
+}
+
+func documentIDs(t *testing.T, seq iter.Seq2[evotor.DocumentShort, error]) []string {
+	t.Helper()
+	var ids []string
+	for doc, err := range seq {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, doc.ID)
+	}
+	return ids
+}
@@ -0,0 +1,108 @@
+package evotor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	BaselinePrevious      = "previous"
+	BaselinePreviousMonth = "previous_month"
+	BaselineLastYear      = "last_year"
+	baselineCustom        = "custom"
+)
+
+// BaselinePeriod derives the period to compare from..to against:
+//   - previous: the same length right before, so a week is compared with the
+//     week before it;
+//   - previous_month: the same dates one month earlier;
+//   - last_year: the same dates one year earlier.
+func BaselinePeriod(from, to time.Time, baseline string) (time.Time, time.Time, error) {
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("comparison needs a period with from before to")
+	}
+	switch strings.ToLower(strings.TrimSpace(baseline)) {
+	case "", BaselinePrevious:
+		// Periods usually end at 23:59:59; rounding up keeps whole days whole.
+		length := (to.Sub(from) + time.Minute - 1).Truncate(time.Minute)
+		return from.Add(-length), to.Add(-length), nil
+	case BaselinePreviousMonth:
+		return shiftMonths(from, -1), shiftMonths(to, -1), nil
+	case BaselineLastYear:
+		return shiftMonths(from, -12), shiftMonths(to, -12), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported baseline %q: use previous, previous_month or last_year", baseline)
+	}
+}
+
+// shiftMonths moves t by months, clamping the day to the target month so
+// March 31 minus a month is the end of February rather than March 3.
+func shiftMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := first.AddDate(0, months, 0)
+	lastDay := target.AddDate(0, 1, -1).Day()
+	return target.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// CompareSalesMetrics computes sales metrics for a period and a baseline
+// period with absolute and percentage change. baseline names how the
+// baseline was chosen and is only echoed back; empty means custom dates.
+func (c *Client) CompareSalesMetrics(ctx context.Context, from, to, baseFrom, baseTo time.Time, storeID *string, documentType DocumentType, baseline string) (SalesComparison, error) {
+	if !c.hasToken() {
+		return SalesComparison{}, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return SalesComparison{}, err
+	}
+	documentType = documentType.orDefault()
+	baseline = strings.ToLower(strings.TrimSpace(baseline))
+	if baseline == "" {
+		baseline = baselineCustom
+	}
+
+	current, _, err := c.salesMetrics(ctx, resolvedStoreID, from, to, documentType)
+	if err != nil {
+		return SalesComparison{}, err
+	}
+	previous, _, err := c.salesMetrics(ctx, resolvedStoreID, baseFrom, baseTo, documentType)
+	if err != nil {
+		return SalesComparison{}, err
+	}
+
+	return SalesComparison{
+		StoreID:      resolvedStoreID,
+		DocumentType: documentType,
+		Baseline:     baseline,
+		Current:      current,
+		Previous:     previous,
+		Changes: SalesChanges{
+			Count:           metricChange(current.Count, previous.Count),
+			TotalSum:        metricChange(current.TotalSum, previous.TotalSum),
+			AverageReceipt:  metricChange(current.AverageReceipt, previous.AverageReceipt),
+			MedianReceipt:   metricChange(current.MedianReceipt, previous.MedianReceipt),
+			ItemsPerReceipt: metricChange(current.ItemsPerReceipt, previous.ItemsPerReceipt),
+		},
+	}, nil
+}
+
+// metricChange leaves Percent nil when the baseline is zero. Fractional
+// deltas are rounded to two decimals like the metrics themselves.
+func metricChange[T int | float64 | Money](current, previous T) MetricChange[T] {
+	delta := current - previous
+	if d, ok := any(delta).(float64); ok {
+		delta = T(round2(d))
+	}
+	change := MetricChange[T]{
+		Current:  current,
+		Previous: previous,
+		Delta:    delta,
+	}
+	if previous != 0 {
+		pct := percent(float64(current-previous), float64(previous))
+		change.Percent = &pct
+	}
+	return change
+}
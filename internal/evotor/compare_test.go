@@ -0,0 +1,85 @@
+package evotor
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestShiftMonths(t *testing.T) {
+	tests := []struct {
+		t      time.Time
+		months int
+		want   time.Time
+	}{
+		{time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC), -1, time.Date(2026, 2, 28, 23, 59, 59, 0, time.UTC)},
+		{time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), -1, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), -12, time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC), -1, time.Date(2025, 12, 15, 10, 0, 0, 0, time.UTC)},
+		{time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC), 1, time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := shiftMonths(tt.t, tt.months); !got.Equal(tt.want) {
+			t.Errorf("shiftMonths(%v, %d) = %v, want %v", tt.t, tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestBaselinePeriod(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+	tests := []struct {
+		baseline         string
+		wantFrom, wantTo time.Time
+	}{
+		{"", time.Date(2026, 1, 29, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 23, 59, 59, 0, time.UTC)},
+		{BaselinePrevious, time.Date(2026, 1, 29, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 23, 59, 59, 0, time.UTC)},
+		{BaselinePreviousMonth, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 23, 59, 59, 0, time.UTC)},
+		{" Last_Year ", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		gotFrom, gotTo, err := BaselinePeriod(from, to, tt.baseline)
+		if err != nil {
+			t.Errorf("%q: %v", tt.baseline, err)
+			continue
+		}
+		if !gotFrom.Equal(tt.wantFrom) || !gotTo.Equal(tt.wantTo) {
+			t.Errorf("%q: %v..%v, want %v..%v", tt.baseline, gotFrom, gotTo, tt.wantFrom, tt.wantTo)
+		}
+	}
+
+	weekFrom := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	weekTo := time.Date(2026, 3, 15, 23, 59, 59, 0, time.UTC)
+	gotFrom, gotTo, err := BaselinePeriod(weekFrom, weekTo, BaselinePrevious)
+	if err != nil || !gotFrom.Equal(weekFrom.AddDate(0, 0, -7)) || !gotTo.Equal(weekTo.AddDate(0, 0, -7)) {
+		t.Errorf("previous week: %v..%v, %v, want the week before", gotFrom, gotTo, err)
+	}
+
+	if _, _, err := BaselinePeriod(to, from, BaselinePrevious); err == nil {
+		t.Error("reversed period: want an error")
+	}
+	if _, _, err := BaselinePeriod(from, to, "quarter"); err == nil {
+		t.Error("unknown baseline: want an error")
+	}
+}
+
+func TestMetricChange(t *testing.T) {
+	money := metricChange(Money(123456), Money(100001))
+	if money.Delta != 23455 || money.Percent == nil || *money.Percent != 23.45 {
+		t.Errorf("money change = %+v, want delta 234.55 and 23.45%%", money)
+	}
+	data, err := json.Marshal(money)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"current":1234.56,"previous":1000.01,"delta":234.55,"percent_change":23.45}`; string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+
+	if items := metricChange(2.3, 2.0); items.Delta != 0.3 {
+		t.Errorf("items delta = %v, want 0.3", items.Delta)
+	}
+	if count := metricChange(5, 0); count.Delta != 5 || count.Percent != nil {
+		t.Errorf("count change from zero = %+v, want delta 5 and no percent", count)
+	}
+}
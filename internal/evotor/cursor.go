@@ -0,0 +1,112 @@
+package evotor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// documentPosition is a point in a document stream: either within a closed
+// UTC day served from the cache (Day, Offset) or within an Evotor page
+// (Remote, Page, Offset). Offset counts documents already consumed there.
+type documentPosition struct {
+	Day    time.Time
+	Remote bool
+	Page   string
+	Offset int
+}
+
+type positionedDocument struct {
+	DocumentFull
+	// next is where the stream resumes after this document.
+	next documentPosition
+}
+
+// documentCursor is the state behind an opaque SearchDocuments continuation
+// token: the query and the position to resume from.
+type documentCursor struct {
+	StoreID  string
+	From     time.Time
+	To       time.Time
+	Position documentPosition
+}
+
+type documentCursorJSON struct {
+	StoreID string `json:"s"`
+	From    string `json:"f,omitempty"`
+	To      string `json:"t,omitempty"`
+	Day     string `json:"d,omitempty"`
+	Remote  bool   `json:"r,omitempty"`
+	Page    string `json:"p,omitempty"`
+	Offset  int    `json:"o,omitempty"`
+}
+
+func (c documentCursor) encode() string {
+	state := documentCursorJSON{
+		StoreID: c.StoreID,
+		From:    formatCursorTime(c.From),
+		To:      formatCursorTime(c.To),
+		Remote:  c.Position.Remote,
+		Page:    c.Position.Page,
+		Offset:  c.Position.Offset,
+	}
+	if !c.Position.Day.IsZero() {
+		state.Day = c.Position.Day.Format(dayKeyLayout)
+	}
+	data, _ := json.Marshal(state)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeDocumentCursor(token string) (documentCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return documentCursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var state documentCursorJSON
+	if err := json.Unmarshal(data, &state); err != nil {
+		return documentCursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if state.StoreID == "" || state.Offset < 0 {
+		return documentCursor{}, ErrInvalidCursor
+	}
+
+	cursor := documentCursor{
+		StoreID: state.StoreID,
+		Position: documentPosition{
+			Remote: state.Remote,
+			Page:   state.Page,
+			Offset: state.Offset,
+		},
+	}
+	if cursor.From, err = parseCursorTime(state.From); err != nil {
+		return documentCursor{}, err
+	}
+	if cursor.To, err = parseCursorTime(state.To); err != nil {
+		return documentCursor{}, err
+	}
+	if state.Day != "" {
+		if cursor.Position.Day, err = time.Parse(dayKeyLayout, state.Day); err != nil {
+			return documentCursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+	}
+	return cursor, nil
+}
+
+func formatCursorTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseCursorTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return t, nil
+}
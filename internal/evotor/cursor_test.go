@@ -0,0 +1,51 @@
+package evotor
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDocumentCursorRoundTrip(t *testing.T) {
+	msk := time.FixedZone("UTC+03:00", 3*3600)
+	cursors := []documentCursor{
+		{StoreID: "s1"},
+		{
+			StoreID:  "s1",
+			From:     time.Date(2025, 12, 1, 0, 0, 0, 0, msk),
+			To:       time.Date(2025, 12, 31, 23, 59, 59, 999000000, msk),
+			Position: documentPosition{Day: time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC), Offset: 3},
+		},
+		{
+			StoreID:  "s1",
+			From:     time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			Position: documentPosition{Remote: true, Page: "cursor-2", Offset: 1},
+		},
+	}
+	for _, want := range cursors {
+		got, err := decodeDocumentCursor(want.encode())
+		if err != nil {
+			t.Fatalf("decode %+v: %v", want, err)
+		}
+		if got.StoreID != want.StoreID || !got.From.Equal(want.From) || !got.To.Equal(want.To) ||
+			!got.Position.Day.Equal(want.Position.Day) || got.Position.Remote != want.Position.Remote ||
+			got.Position.Page != want.Position.Page || got.Position.Offset != want.Position.Offset {
+			t.Errorf("round trip = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestDecodeDocumentCursorRejectsGarbage(t *testing.T) {
+	for _, token := range []string{
+		"not base64!",
+		"bm90IGpzb24",              // "not json"
+		"eyJvIjoxfQ",               // {"o":1}: no store
+		"eyJzIjoiczEiLCJvIjotMX0",  // negative offset
+		"eyJzIjoiczEiLCJmIjoieCJ9", // bad from
+		"eyJzIjoiczEiLCJkIjoieCJ9", // bad day
+	} {
+		if _, err := decodeDocumentCursor(token); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("decode %q: err = %v, want ErrInvalidCursor", token, err)
+		}
+	}
+}
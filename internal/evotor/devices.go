@@ -0,0 +1,94 @@
+package evotor
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (c *Client) ListDevices(ctx context.Context, storeID *string) ([]Device, error) {
+	if !c.hasToken() {
+		return nil, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	return c.storeDevices(ctx, resolvedStoreID)
+}
+
+func (c *Client) storeDevices(ctx context.Context, storeID string) ([]Device, error) {
+	all, err := listAll[Device](ctx, c, "/devices", nil)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]Device, 0, len(all))
+	for _, device := range all {
+		if device.StoreID == storeID {
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+// GetSalesByDevice breaks a period down by cash register, sorted by revenue.
+func (c *Client) GetSalesByDevice(ctx context.Context, from, to time.Time, storeID *string, documentType DocumentType) (DeviceSales, error) {
+	if !c.hasToken() {
+		return DeviceSales{}, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return DeviceSales{}, err
+	}
+	documentType = documentType.orDefault()
+
+	names := map[string]string{}
+	devices, err := c.storeDevices(ctx, resolvedStoreID)
+	if err != nil {
+		c.logger.Warn("device names unavailable", zap.String("store_id", resolvedStoreID), zap.Error(err))
+	}
+	for _, device := range devices {
+		names[device.ID] = device.Name
+	}
+
+	rows := map[string]*DeviceSalesRow{}
+	for _, device := range devices {
+		rows[device.ID] = &DeviceSalesRow{DeviceID: device.ID, DeviceName: device.Name}
+	}
+	for doc, err := range c.documentsSeq(ctx, resolvedStoreID, from, to) {
+		if err != nil {
+			return DeviceSales{}, err
+		}
+		if !documentType.matches(doc.Type) {
+			continue
+		}
+		row, ok := rows[doc.DeviceID]
+		if !ok {
+			row = &DeviceSalesRow{DeviceID: doc.DeviceID, DeviceName: names[doc.DeviceID]}
+			rows[doc.DeviceID] = row
+		}
+		row.Count++
+		row.Revenue += doc.Total
+	}
+
+	result := DeviceSales{
+		StoreID:      resolvedStoreID,
+		DocumentType: documentType,
+		Devices:      make([]DeviceSalesRow, 0, len(rows)),
+	}
+	result.From, result.To = periodBounds(from, to)
+	for _, row := range rows {
+		row.AverageReceipt = averageReceipt(row.Revenue, row.Count)
+		result.Devices = append(result.Devices, *row)
+	}
+	sort.Slice(result.Devices, func(i, j int) bool {
+		a, b := result.Devices[i], result.Devices[j]
+		if a.Revenue != b.Revenue {
+			return a.Revenue > b.Revenue
+		}
+		return a.DeviceID < b.DeviceID
+	})
+	return result, nil
+}
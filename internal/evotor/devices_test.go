@@ -0,0 +1,58 @@
+package evotor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+)
+
+func TestSalesByDevice(t *testing.T) {
+	client, _ := newStoreClient(t, map[string]string{
+		"devices.json": `[
+{"id": "dev-1", "name": "Касса 1", "store_id": "s1"},
+{"id": "dev-2", "name": "Касса 2", "store_id": "s1"},
+{"id": "dev-other", "name": "Чужая касса", "store_id": "s2"}
+]`,
+		"stores/s1/documents.json": `[
+{"id": "a", "type": "SELL", "device_id": "dev-1", "close_date": "2026-03-01T10:00:00.000+0000", "body": {"result_sum": 100}},
+{"id": "b", "type": "SELL", "device_id": "dev-1", "close_date": "2026-03-01T11:00:00.000+0000", "body": {"result_sum": 300}},
+{"id": "payback", "type": "PAYBACK", "device_id": "dev-2", "close_date": "2026-03-01T12:00:00.000+0000", "body": {"result_sum": 500}},
+{"id": "c", "type": "SELL", "device_id": "dev-gone", "close_date": "2026-03-01T13:00:00.000+0000", "body": {"result_sum": 50}}
+]`,
+	}, nil)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+
+	got, err := client.GetSalesByDevice(context.Background(), from, to, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.DocumentType != evotor.DocumentSell {
+		t.Errorf("document type = %s, want SELL", got.DocumentType)
+	}
+	// dev-2 only has a refund and is still listed; dev-gone is no longer
+	// returned by /devices and keeps its ID without a name.
+	want := []evotor.DeviceSalesRow{
+		{DeviceID: "dev-1", DeviceName: "Касса 1", Count: 2, Revenue: 40000, AverageReceipt: 20000},
+		{DeviceID: "dev-gone", Count: 1, Revenue: 5000, AverageReceipt: 5000},
+		{DeviceID: "dev-2", DeviceName: "Касса 2"},
+	}
+	if len(got.Devices) != len(want) {
+		t.Fatalf("devices = %+v, want %d rows", got.Devices, len(want))
+	}
+	for i, w := range want {
+		if got.Devices[i] != w {
+			t.Errorf("row %d = %+v, want %+v", i, got.Devices[i], w)
+		}
+	}
+
+	got, err = client.GetSalesByDevice(context.Background(), from, to, nil, evotor.DocumentPayback)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Devices) != 2 || got.Devices[0].DeviceID != "dev-2" || got.Devices[0].Revenue != 50000 || got.Devices[1].Count != 0 {
+		t.Errorf("paybacks = %+v, want dev-2 first and dev-1 with no documents", got.Devices)
+	}
+}
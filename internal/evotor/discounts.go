@@ -0,0 +1,147 @@
+package evotor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultDiscountProductsLimit = 10
+
+// Revenue is the position amount after its discount.
+func (p DocumentPosition) Revenue() Money {
+	if p.ResultSum != 0 {
+		return p.ResultSum
+	}
+	return p.Sum
+}
+
+// DiscountSum is the discount given on the position; a markup is negative.
+// Without an explicit position_discount it falls back to the gap between sum
+// and result_sum.
+func (p DocumentPosition) DiscountSum() Money {
+	if p.PositionDiscount != nil {
+		return p.PositionDiscount.Sum
+	}
+	if p.Sum != 0 && p.ResultSum != 0 {
+		return p.Sum - p.ResultSum
+	}
+	return 0
+}
+
+// DocumentDiscountSum is the receipt-level discount, not split by position.
+func (b DocumentBody) DocumentDiscountSum() Money {
+	var total Money
+	for _, discount := range b.DocDiscounts {
+		total += discount.Sum
+	}
+	return total
+}
+
+// DiscountSum is the total discount of the receipt net of markups: positions
+// plus receipt-level discounts, or sum minus result_sum when neither is
+// itemized.
+func (b DocumentBody) DiscountSum() Money {
+	total := b.DocumentDiscountSum()
+	for _, pos := range b.Positions {
+		total += pos.DiscountSum()
+	}
+	if total == 0 && b.Sum != 0 && b.ResultSum != 0 {
+		return b.Sum - b.ResultSum
+	}
+	return total
+}
+
+// GetDiscountReport summarizes discounts given on sales (SELL) for a period:
+// totals, share of discounted receipts and products ranked by position
+// discount. Receipt-level discounts count in totals only. Markups are
+// negative discounts, so gross_sum - discount_sum is always net_sum.
+func (c *Client) GetDiscountReport(ctx context.Context, from, to time.Time, storeID *string, limit int) (DiscountReport, error) {
+	if !c.hasToken() {
+		return DiscountReport{}, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return DiscountReport{}, err
+	}
+	if limit <= 0 {
+		limit = defaultDiscountProductsLimit
+	}
+
+	result := DiscountReport{StoreID: resolvedStoreID}
+	result.From, result.To = periodBounds(from, to)
+
+	stats := map[string]*ProductDiscount{}
+	seenIn := map[string]string{}
+	for doc, err := range c.documentsSeq(ctx, resolvedStoreID, from, to) {
+		if err != nil {
+			return DiscountReport{}, err
+		}
+		if !doc.Type.IsSale() {
+			continue
+		}
+		result.Receipts++
+		result.NetSum += doc.Total
+		discount := doc.Body.DiscountSum()
+		result.GrossSum += doc.Total + discount
+		result.DiscountSum += discount
+		if discount > 0 {
+			result.DiscountedReceipts++
+		}
+		result.DocumentDiscountSum += doc.Body.DocumentDiscountSum()
+
+		for _, pos := range doc.Body.Positions {
+			posDiscount := pos.DiscountSum()
+			result.PositionDiscountSum += posDiscount
+			if posDiscount <= 0 {
+				continue
+			}
+			key := positionKey(pos)
+			if key == "" {
+				continue
+			}
+			stat, ok := stats[key]
+			if !ok {
+				stat = &ProductDiscount{ProductID: pos.ProductID, Name: positionName(pos)}
+				stats[key] = stat
+			}
+			stat.Quantity += pos.Quantity
+			stat.GrossSum += pos.Sum
+			stat.DiscountSum += posDiscount
+			if seenIn[key] != doc.ID {
+				stat.Receipts++
+				seenIn[key] = doc.ID
+			}
+		}
+	}
+
+	result.DiscountedShare = percent(float64(result.DiscountedReceipts), float64(result.Receipts))
+	result.DiscountRate = percent(result.DiscountSum.Float64(), result.GrossSum.Float64())
+
+	products := make([]ProductDiscount, 0, len(stats))
+	for _, stat := range stats {
+		stat.DiscountPct = percent(stat.DiscountSum.Float64(), stat.GrossSum.Float64())
+		products = append(products, *stat)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		a, b := products[i], products[j]
+		if a.DiscountSum != b.DiscountSum {
+			return a.DiscountSum > b.DiscountSum
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	result.TotalProducts = len(products)
+	if len(products) > limit {
+		products = products[:limit]
+	}
+	result.Products = products
+	return result, nil
+}
+
+func percent(part, whole float64) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return round2(part / whole * 100)
+}
@@ -0,0 +1,103 @@
+package evotor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+)
+
+func TestPositionDiscountSum(t *testing.T) {
+	tests := []struct {
+		name string
+		pos  evotor.DocumentPosition
+		want evotor.Money
+	}{
+		{"explicit discount wins", evotor.DocumentPosition{Sum: 10000, ResultSum: 9500, PositionDiscount: &evotor.Discount{Sum: 1000}}, 1000},
+		{"gap between sum and result_sum", evotor.DocumentPosition{Sum: 10000, ResultSum: 9000}, 1000},
+		{"markup", evotor.DocumentPosition{Sum: 10000, ResultSum: 11000}, -1000},
+		{"no result_sum", evotor.DocumentPosition{Sum: 10000}, 0},
+		{"no sum", evotor.DocumentPosition{ResultSum: 10000}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.pos.DiscountSum(); got != tt.want {
+			t.Errorf("%s: DiscountSum = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDocumentDiscountSum(t *testing.T) {
+	tests := []struct {
+		name string
+		body evotor.DocumentBody
+		want evotor.Money
+	}{
+		{
+			"positions and receipt discounts add up",
+			evotor.DocumentBody{
+				Sum: 30000, ResultSum: 27000,
+				Positions:    []evotor.DocumentPosition{{Sum: 10000, ResultSum: 9000}, {Sum: 20000, PositionDiscount: &evotor.Discount{Sum: 1500}}},
+				DocDiscounts: []evotor.Discount{{Sum: 500}},
+			},
+			3000,
+		},
+		{
+			"receipt level only",
+			evotor.DocumentBody{Sum: 10000, ResultSum: 9000, Positions: []evotor.DocumentPosition{{Sum: 10000}}},
+			1000,
+		},
+		{
+			"markup on the receipt",
+			evotor.DocumentBody{Sum: 10000, ResultSum: 10500},
+			-500,
+		},
+		{
+			"no sum",
+			evotor.DocumentBody{ResultSum: 10000},
+			0,
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.body.DiscountSum(); got != tt.want {
+			t.Errorf("%s: DiscountSum = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDiscountReportMarkups(t *testing.T) {
+	documents := `[
+{"id": "a", "type": "SELL", "close_date": "2026-03-01T10:00:00.000+0000", "body": {"sum": 100, "result_sum": 80, "positions": [
+  {"product_id": "p-milk", "name": "Молоко", "quantity": 1, "sum": 100, "result_sum": 90}],
+  "doc_discounts": [{"discount_sum": 10}]}},
+{"id": "b", "type": "SELL", "close_date": "2026-03-01T11:00:00.000+0000", "body": {"sum": 50, "result_sum": 60, "positions": [
+  {"product_id": "p-bread", "name": "Хлеб", "quantity": 1, "sum": 50, "result_sum": 60}]}},
+{"id": "c", "type": "SELL", "close_date": "2026-03-01T12:00:00.000+0000", "body": {"sum": 40, "result_sum": 40}},
+{"id": "payback", "type": "PAYBACK", "close_date": "2026-03-01T13:00:00.000+0000", "body": {"sum": 100, "result_sum": 50}}
+]`
+	client, _ := newStoreClient(t, map[string]string{"stores/s1/documents.json": documents}, nil)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+
+	got, err := client.GetDiscountReport(context.Background(), from, to, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// b is marked up by 10.00, which offsets the discounts of a.
+	if got.Receipts != 3 || got.DiscountedReceipts != 1 {
+		t.Errorf("receipts = %d, discounted = %d; want 3 and 1", got.Receipts, got.DiscountedReceipts)
+	}
+	if got.NetSum != 18000 || got.DiscountSum != 1000 || got.GrossSum != 19000 {
+		t.Errorf("net %s, discount %s, gross %s; want 180.00, 10.00, 190.00", got.NetSum, got.DiscountSum, got.GrossSum)
+	}
+	if got.GrossSum-got.DiscountSum != got.NetSum {
+		t.Errorf("gross %s - discount %s != net %s", got.GrossSum, got.DiscountSum, got.NetSum)
+	}
+	if got.PositionDiscountSum != 0 || got.DocumentDiscountSum != 1000 {
+		t.Errorf("position %s, document %s; want 0.00 and 10.00", got.PositionDiscountSum, got.DocumentDiscountSum)
+	}
+	// Only real discounts rank products.
+	if len(got.Products) != 1 || got.Products[0].ProductID != "p-milk" || got.Products[0].DiscountSum != 1000 || got.Products[0].DiscountPct != 10 {
+		t.Errorf("products = %+v, want milk with 10.00 (10%%)", got.Products)
+	}
+}
@@ -0,0 +1,245 @@
+package evotor
+
+import (
+	"container/list"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	dayKeyLayout = "2006-01-02"
+	// documentDaySettle is how long after its end a UTC day is treated as
+	// closed: late uploads from offline registers land within this window.
+	documentDaySettle = 24 * time.Hour
+	// documentCacheVersion is bumped whenever cached documents gain fields;
+	// days written by older versions are fetched again. Unversioned (0)
+	// days lack the cashier IDs (OpenUserID, CloseUserID), payments and
+	// discounts; 1 has them; 2 stores documents as Evotor sent them.
+	documentCacheVersion = 2
+	// documentMemoryDays caps the days kept in memory: about a quarter of one
+	// store. With a disk cache only their document IDs are kept.
+	documentMemoryDays = 92
+)
+
+type documentDay struct {
+	Version int    `json:"version"`
+	StoreID string `json:"store_id"`
+	Day     string `json:"day"`
+	// Documents are stored as Evotor sent them, so fields the typed view
+	// does not model survive the cache.
+	Documents []json.RawMessage `json:"documents"`
+}
+
+// documentStore caches documents of closed UTC days per store. With dir set
+// they are kept only as <dir>/documents/<store_id>/<YYYY-MM-DD>.json;
+// otherwise the documentMemoryDays most recently used days stay in memory.
+type documentStore struct {
+	dir string
+
+	mu sync.Mutex
+	// days holds the in-memory days, most recently used first.
+	days  *list.List
+	byDay map[documentDayKey]*list.Element
+	// index maps store and document IDs to the in-memory day holding them;
+	// entries leave together with their day.
+	index map[string]map[string]string
+}
+
+type documentDayKey struct {
+	storeID string
+	day     string
+}
+
+type memoryDay struct {
+	key documentDayKey
+	// docs is nil with a disk cache, where the day is read back from its file.
+	docs []DocumentFull
+	ids  []string
+}
+
+func newDocumentStore(dir string) *documentStore {
+	return &documentStore{
+		dir:   dir,
+		days:  list.New(),
+		byDay: map[documentDayKey]*list.Element{},
+		index: map[string]map[string]string{},
+	}
+}
+
+func (s *documentStore) load(storeID string, day time.Time) ([]DocumentFull, bool, error) {
+	key := day.Format(dayKeyLayout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dir == "" {
+		elem, ok := s.byDay[documentDayKey{storeID, key}]
+		if !ok {
+			return nil, false, nil
+		}
+		s.days.MoveToFront(elem)
+		return elem.Value.(*memoryDay).docs, true, nil
+	}
+
+	docs, found, err := s.read(storeID, key)
+	if found {
+		s.remember(storeID, key, docs)
+	}
+	return docs, found, err
+}
+
+func (s *documentStore) save(storeID string, day time.Time, docs []DocumentFull) error {
+	key := day.Format(dayKeyLayout)
+	if docs == nil {
+		docs = []DocumentFull{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dir == "" {
+		s.remember(storeID, key, docs)
+		return nil
+	}
+
+	cached := documentDay{Version: documentCacheVersion, StoreID: storeID, Day: key, Documents: make([]json.RawMessage, 0, len(docs))}
+	for _, doc := range docs {
+		raw, err := doc.rawJSON()
+		if err != nil {
+			return fmt.Errorf("encode document cache: %w", err)
+		}
+		cached.Documents = append(cached.Documents, raw)
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("encode document cache: %w", err)
+	}
+	if err := writeFileAtomic(s.path(storeID, key), data); err != nil {
+		return err
+	}
+	s.remember(storeID, key, docs)
+	return nil
+}
+
+// lookup finds a document of a cached day by its ID.
+func (s *documentStore) lookup(storeID, docID string) (DocumentFull, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.index[storeID][docID]
+	if !ok {
+		return DocumentFull{}, false
+	}
+	var docs []DocumentFull
+	if s.dir == "" {
+		docs = s.byDay[documentDayKey{storeID, key}].Value.(*memoryDay).docs
+	} else {
+		var found bool
+		var err error
+		if docs, found, err = s.read(storeID, key); err != nil || !found {
+			return DocumentFull{}, false
+		}
+	}
+	for _, doc := range docs {
+		if doc.ID == docID {
+			return doc, true
+		}
+	}
+	return DocumentFull{}, false
+}
+
+func (s *documentStore) read(storeID, key string) ([]DocumentFull, bool, error) {
+	data, err := os.ReadFile(s.path(storeID, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("read document cache: %w", err)
+	}
+	var cached documentDay
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, false, fmt.Errorf("decode document cache: %w", err)
+	}
+	if cached.Version != documentCacheVersion {
+		return nil, false, nil
+	}
+	docs := make([]DocumentFull, len(cached.Documents))
+	for i, raw := range cached.Documents {
+		if err := json.Unmarshal(raw, &docs[i]); err != nil {
+			return nil, false, fmt.Errorf("decode document cache: %w", err)
+		}
+	}
+	return docs, true, nil
+}
+
+// remember keeps a day in memory, its documents only without a disk cache,
+// evicting the least recently used one past documentMemoryDays.
+func (s *documentStore) remember(storeID, key string, docs []DocumentFull) {
+	day := &memoryDay{key: documentDayKey{storeID, key}, ids: make([]string, len(docs))}
+	for i, doc := range docs {
+		day.ids[i] = doc.ID
+	}
+	if s.dir == "" {
+		day.docs = docs
+	}
+	if elem, ok := s.byDay[day.key]; ok {
+		s.unindexDay(elem.Value.(*memoryDay))
+		elem.Value = day
+		s.days.MoveToFront(elem)
+	} else {
+		s.byDay[day.key] = s.days.PushFront(day)
+	}
+	s.indexDay(day)
+
+	for s.days.Len() > documentMemoryDays {
+		oldest := s.days.Remove(s.days.Back()).(*memoryDay)
+		delete(s.byDay, oldest.key)
+		s.unindexDay(oldest)
+	}
+}
+
+func (s *documentStore) indexDay(day *memoryDay) {
+	if s.index[day.key.storeID] == nil {
+		s.index[day.key.storeID] = map[string]string{}
+	}
+	for _, id := range day.ids {
+		s.index[day.key.storeID][id] = day.key.day
+	}
+}
+
+func (s *documentStore) unindexDay(day *memoryDay) {
+	for _, id := range day.ids {
+		if s.index[day.key.storeID][id] == day.key.day {
+			delete(s.index[day.key.storeID], id)
+		}
+	}
+}
+
+func (s *documentStore) path(storeID, key string) string {
+	return filepath.Join(s.dir, "documents", url.PathEscape(storeID), key+".json")
+}
+
+func dayClosed(day, now time.Time) bool {
+	return !day.AddDate(0, 0, 1).Add(documentDaySettle).After(now)
+}
+
+func utcDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// documentInRange reports whether doc closed within [from, to]. A document
+// whose close date cannot be parsed is out of every range.
+func documentInRange(doc DocumentFull, from, to time.Time) (bool, error) {
+	closed, err := ParseTime(doc.CloseDate)
+	if err != nil {
+		return false, fmt.Errorf("document %s: %w", doc.ID, err)
+	}
+	return !closed.Before(from) && !closed.After(to), nil
+}
@@ -0,0 +1,145 @@
+package evotor
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var testDay = time.Date(2025, 12, 5, 0, 0, 0, 0, time.UTC)
+
+func TestDocumentStoreDropsOtherVersions(t *testing.T) {
+	dir := t.TempDir()
+	doc := json.RawMessage(`{"id":"d1","type":"SELL","close_date":"2025-12-05T10:00:00.000+0000"}`)
+	for _, version := range []int{0, 1, documentCacheVersion + 1} {
+		data, err := json.Marshal(documentDay{Version: version, StoreID: "s1", Day: "2025-12-05", Documents: []json.RawMessage{doc}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writeFileAtomic(newDocumentStore(dir).path("s1", "2025-12-05"), data); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok, err := newDocumentStore(dir).load("s1", testDay); err != nil || ok {
+			t.Errorf("version %d: load ok = %v, err = %v, want a miss", version, ok, err)
+		}
+	}
+}
+
+func TestDocumentStoreLookup(t *testing.T) {
+	for _, dir := range []string{"", t.TempDir()} {
+		s := newDocumentStore(dir)
+		if err := s.save("s1", testDay, []DocumentFull{{ID: "d1"}, {ID: "d2"}}); err != nil {
+			t.Fatal(err)
+		}
+		if doc, ok := s.lookup("s1", "d2"); !ok || doc.ID != "d2" {
+			t.Errorf("dir %q: lookup d2 = %+v, %v, want the saved document", dir, doc, ok)
+		}
+		if _, ok := s.lookup("s2", "d2"); ok {
+			t.Errorf("dir %q: lookup in another store found d2", dir)
+		}
+		if _, ok := s.lookup("s1", "d3"); ok {
+			t.Errorf("dir %q: lookup found unknown d3", dir)
+		}
+	}
+}
+
+func TestDocumentStoreKeepsDiskDaysOutOfMemory(t *testing.T) {
+	s := newDocumentStore(t.TempDir())
+	if err := s.save("s1", testDay, []DocumentFull{{ID: "d1"}}); err != nil {
+		t.Fatal(err)
+	}
+	if docs, ok, err := s.load("s1", testDay); err != nil || !ok || len(docs) != 1 {
+		t.Fatalf("load = %v, %v, %v, want the saved day", docs, ok, err)
+	}
+	for elem := s.days.Front(); elem != nil; elem = elem.Next() {
+		if day := elem.Value.(*memoryDay); day.docs != nil {
+			t.Errorf("day %s keeps %d documents in memory, want none with a disk cache", day.key.day, len(day.docs))
+		}
+	}
+}
+
+func TestDocumentStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	s := newDocumentStore("")
+	for i := range documentMemoryDays {
+		if err := s.save("s1", testDay.AddDate(0, 0, i), []DocumentFull{{ID: fmt.Sprint(i)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Touching the first day makes the second the least recently used.
+	if _, ok, _ := s.load("s1", testDay); !ok {
+		t.Fatal("first day not cached")
+	}
+	if err := s.save("s1", testDay.AddDate(0, 0, documentMemoryDays), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if s.days.Len() != documentMemoryDays {
+		t.Errorf("days in memory = %d, want %d", s.days.Len(), documentMemoryDays)
+	}
+	if _, ok, _ := s.load("s1", testDay); !ok {
+		t.Error("recently used day evicted")
+	}
+	if _, ok, _ := s.load("s1", testDay.AddDate(0, 0, 1)); ok {
+		t.Error("least recently used day kept")
+	}
+	if _, ok := s.lookup("s1", "1"); ok {
+		t.Error("lookup found a document of an evicted day")
+	}
+	if _, ok := s.lookup("s1", "0"); !ok {
+		t.Error("lookup lost a document of a kept day")
+	}
+}
+
+func TestDocumentStoreEvictsDiskDaysFromIndex(t *testing.T) {
+	s := newDocumentStore(t.TempDir())
+	for i := range documentMemoryDays + 1 {
+		if err := s.save("s1", testDay.AddDate(0, 0, i), []DocumentFull{{ID: fmt.Sprint(i)}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := len(s.index["s1"]); got != documentMemoryDays {
+		t.Errorf("indexed documents = %d, want %d", got, documentMemoryDays)
+	}
+	if _, ok := s.lookup("s1", "0"); ok {
+		t.Error("lookup found a document of an evicted day")
+	}
+	if _, ok := s.lookup("s1", "1"); !ok {
+		t.Error("lookup lost a document of a kept day")
+	}
+
+	// The evicted day is still on disk; loading it indexes it again.
+	if _, ok, err := s.load("s1", testDay); err != nil || !ok {
+		t.Fatalf("load evicted day = %v, %v, want it read from disk", ok, err)
+	}
+	if doc, ok := s.lookup("s1", "0"); !ok || doc.ID != "0" {
+		t.Errorf("lookup after load = %+v, %v, want the document", doc, ok)
+	}
+	if got := len(s.index["s1"]); got != documentMemoryDays {
+		t.Errorf("indexed documents after load = %d, want %d", got, documentMemoryDays)
+	}
+}
+
+func TestDocumentInRange(t *testing.T) {
+	from := testDay
+	to := testDay.Add(24*time.Hour - time.Second)
+	tests := []struct {
+		closeDate string
+		want      bool
+		wantErr   bool
+	}{
+		{"2025-12-05T00:00:00.000+0000", true, false},
+		{"2025-12-05T23:59:59.000+0000", true, false},
+		{"2025-12-06T00:00:00.000+0000", false, false},
+		{"2025-12-05T09:00:00.000+1000", false, false},
+		{"", false, true},
+		{"05.12.2025", false, true},
+	}
+	for _, tt := range tests {
+		got, err := documentInRange(DocumentFull{ID: "d1", CloseDate: tt.closeDate}, from, to)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("documentInRange(%q) = %v, %v; want %v, error %v", tt.closeDate, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
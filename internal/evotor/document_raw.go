@@ -0,0 +1,140 @@
+package evotor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// UnmarshalJSON decodes the typed view and keeps the original JSON in Raw.
+func (d *DocumentFull) UnmarshalJSON(data []byte) error {
+	type plain DocumentFull
+	var doc plain
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	*d = DocumentFull(doc)
+	d.Total = pickDocumentTotal(d.Body)
+	d.Raw = bytes.Clone(data)
+	return nil
+}
+
+// rawJSON is the original document, or the typed view when the document did
+// not come from Evotor.
+func (d DocumentFull) rawJSON() (json.RawMessage, error) {
+	if len(d.Raw) > 0 {
+		return d.Raw, nil
+	}
+	type plain DocumentFull
+	return json.Marshal(plain(d))
+}
+
+// Field returns the value at path in the raw document. Path segments are
+// object keys or array indexes separated by dots; "*" takes every element of
+// an array: "body.positions.0.tax.type", "body.positions[*].product_name",
+// "extras.customer_phone". Numbers keep their exact decimal form.
+func (d DocumentFull) Field(path string) (any, bool, error) {
+	segments, err := splitFieldPath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	root, err := d.decodeRaw()
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := lookupField(root, segments)
+	return value, ok, nil
+}
+
+// Fields picks several paths at once, decoding the document only once; see
+// Field.
+func (d DocumentFull) Fields(paths []string) (DocumentFields, error) {
+	segments := make([][]string, len(paths))
+	for i, path := range paths {
+		var err error
+		if segments[i], err = splitFieldPath(path); err != nil {
+			return DocumentFields{}, err
+		}
+	}
+	root, err := d.decodeRaw()
+	if err != nil {
+		return DocumentFields{}, err
+	}
+
+	result := DocumentFields{ID: d.ID, Fields: map[string]any{}}
+	for i, path := range paths {
+		value, ok := lookupField(root, segments[i])
+		if !ok {
+			result.Missing = append(result.Missing, path)
+			continue
+		}
+		result.Fields[path] = value
+	}
+	return result, nil
+}
+
+// decodeRaw decodes the raw document into generic JSON values, keeping
+// numbers as json.Number.
+func (d DocumentFull) decodeRaw() (any, error) {
+	raw, err := d.rawJSON()
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var root any
+	if err := dec.Decode(&root); err != nil {
+		return nil, fmt.Errorf("decode document %s: %w", d.ID, err)
+	}
+	return root, nil
+}
+
+func splitFieldPath(path string) ([]string, error) {
+	path = strings.NewReplacer("[", ".", "]", "").Replace(strings.TrimSpace(path))
+	if path == "" {
+		return nil, fmt.Errorf("empty field path")
+	}
+	segments := strings.Split(path, ".")
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid field path %q", path)
+		}
+	}
+	return segments, nil
+}
+
+func lookupField(value any, segments []string) (any, bool) {
+	if len(segments) == 0 {
+		return value, true
+	}
+	segment, rest := segments[0], segments[1:]
+	switch v := value.(type) {
+	case map[string]any:
+		next, ok := v[segment]
+		if !ok {
+			return nil, false
+		}
+		return lookupField(next, rest)
+	case []any:
+		if segment == "*" {
+			values := []any{}
+			for _, element := range v {
+				if found, ok := lookupField(element, rest); ok {
+					values = append(values, found)
+				}
+			}
+			if len(values) == 0 {
+				return nil, false
+			}
+			return values, true
+		}
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil, false
+		}
+		return lookupField(v[index], rest)
+	}
+	return nil, false
+}
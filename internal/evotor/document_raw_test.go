@@ -0,0 +1,100 @@
+package evotor
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testRawDocument = `{
+	"id": "d1",
+	"type": "SELL",
+	"close_date": "2025-12-07T03:45:00.000+0000",
+	"body": {
+		"positions": [
+			{"product_name": "Молоко", "price": 89.90, "tax": {"type": "NDS_10"}},
+			{"product_name": "Хлеб", "price": 45.00}
+		]
+	},
+	"extras": {"customer_phone": "+79140000000"}
+}`
+
+func TestDocumentField(t *testing.T) {
+	var doc DocumentFull
+	if err := json.Unmarshal([]byte(testRawDocument), &doc); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path   string
+		want   any
+		wantOK bool
+	}{
+		{"extras.customer_phone", "+79140000000", true},
+		{"body.positions.0.tax.type", "NDS_10", true},
+		{"body.positions[1].product_name", "Хлеб", true},
+		{"body.positions.0.price", json.Number("89.90"), true},
+		{"body.positions[*].product_name", []any{"Молоко", "Хлеб"}, true},
+		{"body.positions[*].tax.type", []any{"NDS_10"}, true},
+		{" type ", "SELL", true},
+		{"body.positions[*].discount", nil, false},
+		{"body.positions.2.price", nil, false},
+		{"body.positions.x", nil, false},
+		{"extras.customer_phone.code", nil, false},
+		{"missing", nil, false},
+	}
+	for _, tt := range tests {
+		got, ok, err := doc.Field(tt.path)
+		if err != nil {
+			t.Errorf("Field(%q): %v", tt.path, err)
+			continue
+		}
+		if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Field(%q) = %#v, %v, want %#v, %v", tt.path, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	for _, path := range []string{"", "body..positions", "body.positions[]"} {
+		if _, _, err := doc.Field(path); err == nil {
+			t.Errorf("Field(%q): want an error", path)
+		}
+	}
+}
+
+func TestDocumentFields(t *testing.T) {
+	var doc DocumentFull
+	if err := json.Unmarshal([]byte(testRawDocument), &doc); err != nil {
+		t.Fatal(err)
+	}
+	fields, err := doc.Fields([]string{"extras.customer_phone", "extras.card", "body.positions[*].product_name", "body.positions.1.price"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fields.ID != "d1" || fields.Fields["extras.customer_phone"] != "+79140000000" || !reflect.DeepEqual(fields.Missing, []string{"extras.card"}) {
+		t.Errorf("Fields = %+v, want the phone and extras.card missing", fields)
+	}
+	if got := fields.Fields["body.positions[*].product_name"]; !reflect.DeepEqual(got, []any{"Молоко", "Хлеб"}) {
+		t.Errorf("product names = %#v, want both positions", got)
+	}
+	if got := fields.Fields["body.positions.1.price"]; got != json.Number("45.00") {
+		t.Errorf("price = %#v, want 45.00 as sent", got)
+	}
+}
+
+func TestDocumentFieldsErrors(t *testing.T) {
+	doc := DocumentFull{ID: "d1", Raw: json.RawMessage(`{"id": "d1"`)}
+	// Paths are checked before the document is decoded.
+	if _, err := doc.Fields([]string{"extras.card", "extras..card"}); err == nil || !strings.Contains(err.Error(), "invalid field path") {
+		t.Errorf("Fields with a bad path: err = %v, want the path error", err)
+	}
+	if _, err := doc.Fields([]string{"extras.card", "type"}); err == nil || !strings.Contains(err.Error(), "decode document d1") {
+		t.Errorf("Fields of a broken document: err = %v, want the decode error", err)
+	}
+}
+
+func TestDocumentFieldWithoutRaw(t *testing.T) {
+	doc := DocumentFull{ID: "d1", Type: "SELL"}
+	if got, ok, err := doc.Field("type"); err != nil || !ok || got != "SELL" {
+		t.Errorf("Field(type) = %#v, %v, %v, want the typed view's SELL", got, ok, err)
+	}
+}
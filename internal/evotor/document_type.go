@@ -0,0 +1,153 @@
+package evotor
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DocumentType is the type of an Evotor document. Unknown values coming from
+// the API are kept as is so new types still show up in reports.
+//
+// Reports take a DocumentType as a filter: empty counts sales (SELL) and
+// DocumentTypeAll counts every type.
+type DocumentType string
+
+const (
+	// Receipts.
+	DocumentSell    DocumentType = "SELL"
+	DocumentPayback DocumentType = "PAYBACK"
+	DocumentBuy     DocumentType = "BUY"
+	DocumentBuyback DocumentType = "BUYBACK"
+
+	// Cash drawer and shift.
+	DocumentCashIncome   DocumentType = "CASH_INCOME"
+	DocumentCashOutcome  DocumentType = "CASH_OUTCOME"
+	DocumentOpenSession  DocumentType = "OPEN_SESSION"
+	DocumentCloseSession DocumentType = "CLOSE_SESSION"
+
+	// Stock documents. RETURN is a return to the supplier, not a customer
+	// return; customers return goods with PAYBACK.
+	DocumentAccept      DocumentType = "ACCEPT"
+	DocumentReturn      DocumentType = "RETURN"
+	DocumentWriteOff    DocumentType = "WRITE_OFF"
+	DocumentInventory   DocumentType = "INVENTORY"
+	DocumentRevaluation DocumentType = "REVALUATION"
+	DocumentOpenTare    DocumentType = "OPEN_TARE"
+
+	DocumentUnknown DocumentType = "UNKNOWN"
+)
+
+// DocumentTypeAll is a filter value selecting every document type.
+const DocumentTypeAll DocumentType = "ALL"
+
+// DocumentTypes lists every known document type.
+var DocumentTypes = []DocumentType{
+	DocumentSell, DocumentPayback, DocumentBuy, DocumentBuyback,
+	DocumentCashIncome, DocumentCashOutcome, DocumentOpenSession, DocumentCloseSession,
+	DocumentAccept, DocumentReturn, DocumentWriteOff, DocumentInventory, DocumentRevaluation, DocumentOpenTare,
+}
+
+// ParseDocumentType parses a document type filter. Empty means sales (SELL),
+// "ALL" selects every type.
+func ParseDocumentType(value string) (DocumentType, error) {
+	docType := normalizeDocumentType(value)
+	switch {
+	case strings.TrimSpace(value) == "":
+		return DocumentSell, nil
+	case docType == DocumentTypeAll || docType.Known():
+		return docType, nil
+	default:
+		return "", fmt.Errorf("unsupported document type %q: use ALL or one of %s", value, strings.Join(documentTypeNames(), ", "))
+	}
+}
+
+func normalizeDocumentType(value string) DocumentType {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	if value == "" {
+		return DocumentUnknown
+	}
+	return DocumentType(value)
+}
+
+func (t *DocumentType) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*t = normalizeDocumentType(value)
+	return nil
+}
+
+func (t DocumentType) Known() bool {
+	for _, known := range DocumentTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSale reports a sale receipt.
+func (t DocumentType) IsSale() bool {
+	return t == DocumentSell
+}
+
+// IsReturn reports a customer return.
+func (t DocumentType) IsReturn() bool {
+	return t == DocumentPayback
+}
+
+// IsReceipt reports a fiscal receipt: sales, returns and purchases from
+// customers with their returns.
+func (t DocumentType) IsReceipt() bool {
+	switch t {
+	case DocumentSell, DocumentPayback, DocumentBuy, DocumentBuyback:
+		return true
+	}
+	return false
+}
+
+// IsCashMovement reports cash put into or taken out of the drawer outside
+// of receipts.
+func (t DocumentType) IsCashMovement() bool {
+	return t == DocumentCashIncome || t == DocumentCashOutcome
+}
+
+// IsSession reports a shift opening or closing.
+func (t DocumentType) IsSession() bool {
+	return t == DocumentOpenSession || t == DocumentCloseSession
+}
+
+// IsStock reports a warehouse document that changes balances or prices but
+// not revenue.
+func (t DocumentType) IsStock() bool {
+	switch t {
+	case DocumentAccept, DocumentReturn, DocumentWriteOff, DocumentInventory, DocumentRevaluation, DocumentOpenTare:
+		return true
+	}
+	return false
+}
+
+// matches applies t as a filter with the default of orDefault.
+func (t DocumentType) matches(docType DocumentType) bool {
+	t = t.orDefault()
+	return t == DocumentTypeAll || docType == t
+}
+
+// orDefault applies the default filter every report shares: an empty
+// DocumentType means SELL.
+func (t DocumentType) orDefault() DocumentType {
+	if t == "" {
+		return DocumentSell
+	}
+	return t
+}
+
+func documentTypeNames() []string {
+	names := make([]string, 0, len(DocumentTypes))
+	for _, docType := range DocumentTypes {
+		names = append(names, string(docType))
+	}
+	return names
+}
@@ -0,0 +1,101 @@
+package evotor
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDocumentType(t *testing.T) {
+	tests := []struct {
+		value string
+		want  DocumentType
+	}{
+		{"", DocumentSell},
+		{"  ", DocumentSell},
+		{"sell", DocumentSell},
+		{" Payback ", DocumentPayback},
+		{"all", DocumentTypeAll},
+		{"WRITE_OFF", DocumentWriteOff},
+	}
+	for _, tt := range tests {
+		got, err := ParseDocumentType(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDocumentType(%q) = %q, %v, want %q", tt.value, got, err, tt.want)
+		}
+	}
+	for _, value := range []string{"REFUND", "UNKNOWN", "sale"} {
+		if _, err := ParseDocumentType(value); err == nil {
+			t.Errorf("ParseDocumentType(%q): want an error", value)
+		}
+	}
+}
+
+func TestDocumentTypeUnmarshalJSON(t *testing.T) {
+	var doc struct {
+		Type DocumentType `json:"type"`
+	}
+	for data, want := range map[string]DocumentType{
+		`{"type": "sell"}`:         DocumentSell,
+		`{"type": " FISCAL_X "}`:   "FISCAL_X",
+		`{"type": ""}`:             DocumentUnknown,
+		`{"type": "OPEN_SESSION"}`: DocumentOpenSession,
+	} {
+		if err := json.Unmarshal([]byte(data), &doc); err != nil || doc.Type != want {
+			t.Errorf("Unmarshal(%s) = %q, %v, want %q", data, doc.Type, err, want)
+		}
+	}
+}
+
+func TestDocumentTypeClassification(t *testing.T) {
+	type class struct{ sale, ret, receipt, cash, session, stock bool }
+	tests := map[DocumentType]class{
+		DocumentSell:         {sale: true, receipt: true},
+		DocumentPayback:      {ret: true, receipt: true},
+		DocumentBuy:          {receipt: true},
+		DocumentBuyback:      {receipt: true},
+		DocumentCashIncome:   {cash: true},
+		DocumentCashOutcome:  {cash: true},
+		DocumentOpenSession:  {session: true},
+		DocumentCloseSession: {session: true},
+		DocumentAccept:       {stock: true},
+		DocumentReturn:       {stock: true},
+		DocumentWriteOff:     {stock: true},
+		DocumentInventory:    {stock: true},
+		DocumentRevaluation:  {stock: true},
+		DocumentOpenTare:     {stock: true},
+		DocumentUnknown:      {},
+		"FISCAL_X":           {},
+	}
+	if len(tests)-2 != len(DocumentTypes) {
+		t.Fatalf("test covers %d known types, DocumentTypes has %d", len(tests)-2, len(DocumentTypes))
+	}
+	for docType, want := range tests {
+		got := class{docType.IsSale(), docType.IsReturn(), docType.IsReceipt(), docType.IsCashMovement(), docType.IsSession(), docType.IsStock()}
+		if got != want {
+			t.Errorf("%s: classified %+v, want %+v", docType, got, want)
+		}
+		if docType.Known() != (docType != DocumentUnknown && docType != "FISCAL_X") {
+			t.Errorf("%s: Known = %v", docType, docType.Known())
+		}
+	}
+}
+
+func TestDocumentTypeMatches(t *testing.T) {
+	tests := []struct {
+		filter, docType DocumentType
+		want            bool
+	}{
+		{"", DocumentSell, true},
+		{"", DocumentPayback, false},
+		{"", DocumentUnknown, false},
+		{DocumentTypeAll, DocumentPayback, true},
+		{DocumentTypeAll, DocumentUnknown, true},
+		{DocumentPayback, DocumentPayback, true},
+		{DocumentPayback, DocumentSell, false},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.matches(tt.docType); got != tt.want {
+			t.Errorf("%q.matches(%s) = %v, want %v", tt.filter, tt.docType, got, tt.want)
+		}
+	}
+}
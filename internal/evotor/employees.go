@@ -0,0 +1,109 @@
+package evotor
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// unknownEmployeeName labels documents that carry neither close_user_id nor
+// open_user_id.
+const unknownEmployeeName = "Кассир не указан"
+
+// ListEmployees returns employees attached to the store. Employees without a
+// store list are treated as account-wide and always included.
+func (c *Client) ListEmployees(ctx context.Context, storeID *string) ([]Employee, error) {
+	if !c.hasToken() {
+		return nil, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	return c.storeEmployees(ctx, resolvedStoreID)
+}
+
+func (c *Client) storeEmployees(ctx context.Context, storeID string) ([]Employee, error) {
+	all, err := listAll[Employee](ctx, c, "/employees", nil)
+	if err != nil {
+		return nil, err
+	}
+	employees := make([]Employee, 0, len(all))
+	for _, employee := range all {
+		if len(employee.Stores) == 0 || slices.Contains(employee.Stores, storeID) {
+			employees = append(employees, employee)
+		}
+	}
+	return employees, nil
+}
+
+// GetSalesByEmployee attributes sales and returns of a period to the employee
+// who closed each document, sorted by net revenue.
+func (c *Client) GetSalesByEmployee(ctx context.Context, from, to time.Time, storeID *string) (EmployeeSales, error) {
+	if !c.hasToken() {
+		return EmployeeSales{}, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return EmployeeSales{}, err
+	}
+
+	names := map[string]string{}
+	employees, err := c.storeEmployees(ctx, resolvedStoreID)
+	if err != nil {
+		c.logger.Warn("employee names unavailable", zap.String("store_id", resolvedStoreID), zap.Error(err))
+	}
+	for _, employee := range employees {
+		names[employee.ID] = employee.FullName()
+	}
+
+	rows := map[string]*EmployeeSalesRow{}
+	for doc, err := range c.documentsSeq(ctx, resolvedStoreID, from, to) {
+		if err != nil {
+			return EmployeeSales{}, err
+		}
+		isSale := doc.Type.IsSale()
+		if !isSale && !doc.Type.IsReturn() {
+			continue
+		}
+
+		employeeID := doc.EmployeeID()
+		row, ok := rows[employeeID]
+		if !ok {
+			row = &EmployeeSalesRow{EmployeeID: employeeID, EmployeeName: names[employeeID]}
+			if employeeID == "" {
+				row.EmployeeName = unknownEmployeeName
+			}
+			rows[employeeID] = row
+		}
+		if isSale {
+			row.Sales.Count++
+			row.Sales.Sum += doc.Total
+		} else {
+			row.Returns.Count++
+			row.Returns.Sum += doc.Total
+		}
+	}
+
+	result := EmployeeSales{
+		StoreID:   resolvedStoreID,
+		Employees: make([]EmployeeSalesRow, 0, len(rows)),
+	}
+	result.From, result.To = periodBounds(from, to)
+	for _, row := range rows {
+		row.NetRevenue = row.Sales.Sum - row.Returns.Sum
+		row.AverageReceipt = averageReceipt(row.Sales.Sum, row.Sales.Count)
+		result.Employees = append(result.Employees, *row)
+	}
+	sort.Slice(result.Employees, func(i, j int) bool {
+		a, b := result.Employees[i], result.Employees[j]
+		if a.NetRevenue != b.NetRevenue {
+			return a.NetRevenue > b.NetRevenue
+		}
+		return a.EmployeeID < b.EmployeeID
+	})
+	return result, nil
+}
@@ -0,0 +1,59 @@
+package evotor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+)
+
+func TestSalesByEmployee(t *testing.T) {
+	client, _ := newStoreClient(t, map[string]string{
+		"employees.json": `[
+{"id": "e1", "name": "Анна", "last_name": "Петрова", "stores": ["s1"]},
+{"id": "e2", "name": "Игорь", "last_name": "Смирнов"},
+{"id": "e3", "name": "Ольга", "stores": ["s2"]}
+]`,
+		"stores/s1/documents.json": `[
+{"id": "a", "type": "SELL", "close_user_id": "e1", "close_date": "2026-03-01T10:00:00.000+0000", "body": {"result_sum": 100}},
+{"id": "b", "type": "SELL", "open_user_id": "e2", "close_user_id": "e1", "close_date": "2026-03-01T11:00:00.000+0000", "body": {"result_sum": 200}},
+{"id": "payback", "type": "PAYBACK", "close_user_id": "e1", "close_date": "2026-03-01T12:00:00.000+0000", "body": {"result_sum": 50}},
+{"id": "c", "type": "SELL", "open_user_id": "e2", "close_date": "2026-03-01T13:00:00.000+0000", "body": {"result_sum": 400}},
+{"id": "d", "type": "SELL", "close_date": "2026-03-01T14:00:00.000+0000", "body": {"result_sum": 30}},
+{"id": "cash", "type": "CASH_INCOME", "close_user_id": "e1", "close_date": "2026-03-01T15:00:00.000+0000", "body": {"result_sum": 1000}}
+]`,
+	}, nil)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+
+	got, err := client.GetSalesByEmployee(context.Background(), from, to, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// c falls back to the user who opened it; d has no user at all.
+	want := []evotor.EmployeeSalesRow{
+		{
+			EmployeeID: "e2", EmployeeName: "Смирнов Игорь",
+			Sales: evotor.MetricsAmount{Count: 1, Sum: 40000}, NetRevenue: 40000, AverageReceipt: 40000,
+		},
+		{
+			EmployeeID: "e1", EmployeeName: "Петрова Анна",
+			Sales:      evotor.MetricsAmount{Count: 2, Sum: 30000},
+			Returns:    evotor.MetricsAmount{Count: 1, Sum: 5000},
+			NetRevenue: 25000, AverageReceipt: 15000,
+		},
+		{
+			EmployeeID: "", EmployeeName: "Кассир не указан",
+			Sales: evotor.MetricsAmount{Count: 1, Sum: 3000}, NetRevenue: 3000, AverageReceipt: 3000,
+		},
+	}
+	if len(got.Employees) != len(want) {
+		t.Fatalf("employees = %+v, want %d rows", got.Employees, len(want))
+	}
+	for i, w := range want {
+		if got.Employees[i] != w {
+			t.Errorf("row %d = %+v, want %+v", i, got.Employees[i], w)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package evotortest
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+)
+
+//go:embed fixtures
+var embeddedFixtures embed.FS
+
+type record struct {
+	ID   string
+	Time time.Time
+	Raw  json.RawMessage
+}
+
+type storeFixtures struct {
+	products  []record
+	groups    []record
+	documents []record
+}
+
+type Fixtures struct {
+	stores    []record
+	devices   []record
+	employees []record
+	byID      map[string]*storeFixtures
+}
+
+func DefaultFixtures() (*Fixtures, error) {
+	sub, err := fs.Sub(embeddedFixtures, "fixtures")
+	if err != nil {
+		return nil, fmt.Errorf("open embedded fixtures: %w", err)
+	}
+	return LoadFixtures(sub)
+}
+
+func LoadFixturesDir(dir string) (*Fixtures, error) {
+	return LoadFixtures(os.DirFS(dir))
+}
+
+func LoadFixtures(fsys fs.FS) (*Fixtures, error) {
+	stores, err := readRecords(fsys, "stores.json", "")
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := readRecords(fsys, "devices.json", "")
+	if err != nil {
+		return nil, err
+	}
+
+	employees, err := readRecords(fsys, "employees.json", "")
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := &Fixtures{
+		stores:    stores,
+		devices:   devices,
+		employees: employees,
+		byID:      make(map[string]*storeFixtures, len(stores)),
+	}
+	for _, store := range stores {
+		dir := path.Join("stores", store.ID)
+		products, err := readRecords(fsys, path.Join(dir, "products.json"), "updated_at")
+		if err != nil {
+			return nil, err
+		}
+		groups, err := readRecords(fsys, path.Join(dir, "product-groups.json"), "updated_at")
+		if err != nil {
+			return nil, err
+		}
+		documents, err := readRecords(fsys, path.Join(dir, "documents.json"), "close_date")
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(documents, func(i, j int) bool {
+			return documents[i].Time.Before(documents[j].Time)
+		})
+		fixtures.byID[store.ID] = &storeFixtures{
+			products:  products,
+			groups:    groups,
+			documents: documents,
+		}
+	}
+
+	return fixtures, nil
+}
+
+func (f *Fixtures) store(storeID string) (*storeFixtures, bool) {
+	store, ok := f.byID[storeID]
+	return store, ok
+}
+
+func readRecords(fsys fs.FS, name, timeField string) ([]record, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read fixture %s: %w", name, err)
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(data, &raws); err != nil {
+		return nil, fmt.Errorf("decode fixture %s: %w", name, err)
+	}
+
+	records := make([]record, 0, len(raws))
+	for i, raw := range raws {
+		var fields map[string]any
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, fmt.Errorf("decode fixture %s[%d]: %w", name, i, err)
+		}
+		id, _ := fields["id"].(string)
+		if id == "" {
+			return nil, fmt.Errorf("fixture %s[%d]: missing id", name, i)
+		}
+		rec := record{ID: id, Raw: raw}
+		if timeField != "" {
+			if value, _ := fields[timeField].(string); value != "" {
+				parsed, err := evotor.ParseTime(value)
+				if err != nil {
+					return nil, fmt.Errorf("fixture %s[%d]: %s: %w", name, i, timeField, err)
+				}
+				rec.Time = parsed
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
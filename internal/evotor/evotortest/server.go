@@ -0,0 +1,307 @@
+package evotortest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultPageSize = 100
+
+type Options struct {
+	// Token is the expected bearer token. Empty accepts any non-empty token.
+	Token           string
+	PageSize        int
+	ForbiddenStores []string
+	// RateLimitEvery answers every N-th request with 429 when positive.
+	RateLimitEvery int
+	// RetryAfter is sent with 429 responses. The header carries whole
+	// seconds, so it is rounded up and is at least 1s.
+	RetryAfter time.Duration
+}
+
+type fault struct {
+	status int
+	left   int
+}
+
+type Server struct {
+	fixtures *Fixtures
+	opts     Options
+	mux      *http.ServeMux
+
+	mu       sync.Mutex
+	requests int
+	faults   []fault
+}
+
+func NewServer(fixtures *Fixtures, opts Options) *Server {
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultPageSize
+	}
+	if opts.RetryAfter <= 0 {
+		opts.RetryAfter = time.Second
+	}
+
+	s := &Server{
+		fixtures: fixtures,
+		opts:     opts,
+		mux:      http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /stores", s.handleStores)
+	s.mux.HandleFunc("GET /devices", s.handleDevices)
+	s.mux.HandleFunc("GET /employees", s.handleEmployees)
+	s.mux.HandleFunc("GET /stores/{storeID}/products", s.handleProducts)
+	s.mux.HandleFunc("GET /stores/{storeID}/product-groups", s.handleProductGroups)
+	s.mux.HandleFunc("GET /stores/{storeID}/documents", s.handleDocuments)
+	s.mux.HandleFunc("GET /stores/{storeID}/documents/{docID}", s.handleDocument)
+	return s
+}
+
+func NewTestServer(fixtures *Fixtures, opts Options) (*httptest.Server, *Server) {
+	fake := NewServer(fixtures, opts)
+	return httptest.NewServer(fake), fake
+}
+
+// InjectError makes the next times requests fail with the given status.
+func (s *Server) InjectError(status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, fault{status: status, left: times})
+}
+
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if status, ok := s.nextFault(); ok {
+		s.writeFault(w, status)
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if storeID := storeIDFromPath(r.URL.Path); storeID != "" && slices.Contains(s.opts.ForbiddenStores, storeID) {
+		writeError(w, http.StatusForbidden, "access to store is forbidden")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) nextFault() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if len(s.faults) > 0 {
+		status := s.faults[0].status
+		s.faults[0].left--
+		if s.faults[0].left <= 0 {
+			s.faults = s.faults[1:]
+		}
+		return status, true
+	}
+	if s.opts.RateLimitEvery > 0 && s.requests%s.opts.RateLimitEvery == 0 {
+		return http.StatusTooManyRequests, true
+	}
+	return 0, false
+}
+
+func (s *Server) writeFault(w http.ResponseWriter, status int) {
+	if status == http.StatusTooManyRequests {
+		seconds := int((s.opts.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+	}
+	writeError(w, status, strings.ToLower(http.StatusText(status)))
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	token = strings.TrimSpace(token)
+	if !ok || token == "" {
+		return false
+	}
+	return s.opts.Token == "" || token == s.opts.Token
+}
+
+func (s *Server) handleStores(w http.ResponseWriter, r *http.Request) {
+	s.writePage(w, r, s.fixtures.stores)
+}
+
+func (s *Server) handleDevices(w http.ResponseWriter, r *http.Request) {
+	s.writePage(w, r, s.fixtures.devices)
+}
+
+func (s *Server) handleEmployees(w http.ResponseWriter, r *http.Request) {
+	s.writePage(w, r, s.fixtures.employees)
+}
+
+func (s *Server) handleProducts(w http.ResponseWriter, r *http.Request) {
+	store, ok := s.fixtures.store(r.PathValue("storeID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "store not found")
+		return
+	}
+	s.writePage(w, r, store.products)
+}
+
+func (s *Server) handleProductGroups(w http.ResponseWriter, r *http.Request) {
+	store, ok := s.fixtures.store(r.PathValue("storeID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "store not found")
+		return
+	}
+	s.writePage(w, r, store.groups)
+}
+
+func (s *Server) handleDocuments(w http.ResponseWriter, r *http.Request) {
+	store, ok := s.fixtures.store(r.PathValue("storeID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "store not found")
+		return
+	}
+	s.writePage(w, r, store.documents)
+}
+
+func (s *Server) handleDocument(w http.ResponseWriter, r *http.Request) {
+	store, ok := s.fixtures.store(r.PathValue("storeID"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "store not found")
+		return
+	}
+	docID := r.PathValue("docID")
+	for _, doc := range store.documents {
+		if doc.ID == docID {
+			writeJSON(w, http.StatusOK, doc.Raw)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "document not found")
+}
+
+// writePage serves one page of records. The cursor carries the since/until
+// window of the first request, so later pages need only the cursor.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, records []record) {
+	state, err := pageStateFromRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filtered := state.filter(records)
+	offset := min(state.Offset, len(filtered))
+	end := min(offset+s.opts.PageSize, len(filtered))
+
+	items := make([]json.RawMessage, 0, end-offset)
+	for _, rec := range filtered[offset:end] {
+		items = append(items, rec.Raw)
+	}
+	paging := map[string]string{}
+	if end < len(filtered) {
+		next := state
+		next.Offset = end
+		paging["next_cursor"] = next.encode()
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"items":  items,
+		"paging": paging,
+	})
+}
+
+type pageState struct {
+	Offset int   `json:"o"`
+	Since  int64 `json:"s,omitempty"`
+	Until  int64 `json:"u,omitempty"`
+}
+
+func pageStateFromRequest(r *http.Request) (pageState, error) {
+	query := r.URL.Query()
+	if cursor := query.Get("cursor"); cursor != "" {
+		return decodeCursor(cursor)
+	}
+
+	var state pageState
+	var err error
+	if state.Since, err = parseMillis(query.Get("since")); err != nil {
+		return pageState{}, fmt.Errorf("invalid since: %w", err)
+	}
+	if state.Until, err = parseMillis(query.Get("until")); err != nil {
+		return pageState{}, fmt.Errorf("invalid until: %w", err)
+	}
+	return state, nil
+}
+
+func (p pageState) filter(records []record) []record {
+	if p.Since == 0 && p.Until == 0 {
+		return records
+	}
+
+	filtered := make([]record, 0, len(records))
+	for _, rec := range records {
+		ms := rec.Time.UnixMilli()
+		if p.Since != 0 && ms < p.Since {
+			continue
+		}
+		if p.Until != 0 && ms > p.Until {
+			continue
+		}
+		filtered = append(filtered, rec)
+	}
+	return filtered
+}
+
+func (p pageState) encode() string {
+	raw, _ := json.Marshal(p)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(cursor string) (pageState, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return pageState{}, fmt.Errorf("invalid cursor")
+	}
+	var state pageState
+	if err := json.Unmarshal(raw, &state); err != nil || state.Offset < 0 {
+		return pageState{}, fmt.Errorf("invalid cursor")
+	}
+	return state, nil
+}
+
+func parseMillis(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(value, 10, 64)
+}
+
+func storeIDFromPath(path string) string {
+	rest, ok := strings.CutPrefix(path, "/stores/")
+	if !ok {
+		return ""
+	}
+	storeID, _, _ := strings.Cut(rest, "/")
+	return storeID
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]any{
+		"errors": []map[string]any{{"code": status, "message": message}},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/vnd.evotor.v2+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
@@ -0,0 +1,119 @@
+package evotor
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+const defaultFetchConcurrency = 4
+
+type FetchOptions struct {
+	// Concurrency caps parallel requests; zero uses the client default.
+	Concurrency int
+	// Limit stops fetching once this many documents matched; zero fetches all.
+	Limit int
+	// Match selects documents to return; nil keeps every document.
+	Match func(DocumentFull) bool
+}
+
+// FetchDocuments loads full documents by ID with a bounded worker pool.
+// Matches are returned in the order of ids. With a limit the result holds the
+// first matches in that order: dispatch stops once enough were found and the
+// requests already in flight are allowed to finish.
+func (c *Client) FetchDocuments(ctx context.Context, ids []string, storeID *string, opts FetchOptions) ([]DocumentFull, error) {
+	if !c.hasToken() {
+		return nil, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = c.fetchConcurrency
+	}
+	concurrency = min(concurrency, len(ids))
+
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type match struct {
+		index int
+		doc   DocumentFull
+	}
+
+	var (
+		mu       sync.Mutex
+		matches  []match
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	jobs := make(chan int)
+
+	for range concurrency {
+		wg.Go(func() {
+			for i := range jobs {
+				doc, err := c.getDocument(workerCtx, resolvedStoreID, ids[i])
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				if opts.Match != nil && !opts.Match(doc) {
+					continue
+				}
+				mu.Lock()
+				matches = append(matches, match{index: i, doc: doc})
+				mu.Unlock()
+			}
+		})
+	}
+
+	enough := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil || (opts.Limit > 0 && len(matches) >= opts.Limit)
+	}
+
+dispatch:
+	for i := range ids {
+		if enough() {
+			break
+		}
+		select {
+		case jobs <- i:
+		case <-workerCtx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].index < matches[j].index
+	})
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+	documents := make([]DocumentFull, 0, len(matches))
+	for _, m := range matches {
+		documents = append(documents, m.doc)
+	}
+	return documents, nil
+}
@@ -0,0 +1,106 @@
+package evotor_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+	"simple_answer_llm/internal/evotor/evotortest"
+)
+
+// fixtureDocumentIDs lists the first n documents of the default store.
+func fixtureDocumentIDs(t *testing.T, n int) []string {
+	t.Helper()
+	client, _ := newTestClient(t, evotortest.Options{PageSize: 1000}, nil)
+	ids := documentIDs(t, client.Documents(context.Background(), time.Time{}, time.Time{}, nil))
+	if len(ids) < n {
+		t.Fatalf("fixtures have %d documents, want at least %d", len(ids), n)
+	}
+	return ids[:n]
+}
+
+func TestFetchDocumentsKeepsIDOrder(t *testing.T) {
+	ids := fixtureDocumentIDs(t, 20)
+	slices.Reverse(ids)
+	client, _ := newTestClient(t, evotortest.Options{}, nil)
+
+	docs, err := client.FetchDocuments(context.Background(), ids, nil, evotor.FetchOptions{Concurrency: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(docs) != len(ids) {
+		t.Fatalf("got %d documents, want %d", len(docs), len(ids))
+	}
+	for i, doc := range docs {
+		if doc.ID != ids[i] {
+			t.Fatalf("document %d = %s, want %s", i, doc.ID, ids[i])
+		}
+	}
+}
+
+func TestFetchDocumentsStopsAtLimit(t *testing.T) {
+	ids := fixtureDocumentIDs(t, 20)
+	client, fake := newTestClient(t, evotortest.Options{}, nil)
+
+	// Every other document matches; the first three matches are ids 1, 3, 5.
+	match := func(doc evotor.DocumentFull) bool {
+		return slices.Index(ids, doc.ID)%2 == 1
+	}
+	docs, err := client.FetchDocuments(context.Background(), ids, nil, evotor.FetchOptions{Concurrency: 1, Limit: 3, Match: match})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, doc := range docs {
+		got = append(got, doc.ID)
+	}
+	if want := []string{ids[1], ids[3], ids[5]}; !slices.Equal(got, want) {
+		t.Errorf("documents = %v, want %v", got, want)
+	}
+	// One request may already be dispatched when the limit is reached.
+	if requests := fake.Requests(); requests > 7 {
+		t.Errorf("requests = %d, want dispatch to stop after the limit", requests)
+	}
+}
+
+func TestFetchDocumentsFirstErrorStopsWorkers(t *testing.T) {
+	ids := append([]string{"d0000000-0000-4000-8000-00000000ffff"}, fixtureDocumentIDs(t, 40)...)
+	client, fake := newTestClient(t, evotortest.Options{}, nil)
+
+	_, err := client.FetchDocuments(context.Background(), ids, nil, evotor.FetchOptions{Concurrency: 2})
+	var apiErr *evotor.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want 404 APIError", err)
+	}
+	if requests := fake.Requests(); requests >= len(ids) {
+		t.Errorf("requests = %d, want the remaining documents skipped", requests)
+	}
+}
+
+func TestFetchDocumentsCancelled(t *testing.T) {
+	ids := fixtureDocumentIDs(t, 40)
+	client, fake := newTestClient(t, evotortest.Options{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.FetchDocuments(ctx, ids, nil, evotor.FetchOptions{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled before start: err = %v, want context.Canceled", err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	match := func(evotor.DocumentFull) bool {
+		cancel()
+		return true
+	}
+	if _, err := client.FetchDocuments(ctx, ids, nil, evotor.FetchOptions{Concurrency: 2, Match: match}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled while fetching: err = %v, want context.Canceled", err)
+	}
+	if requests := fake.Requests(); requests >= len(ids) {
+		t.Errorf("requests = %d, want fetching to stop on cancel", requests)
+	}
+}
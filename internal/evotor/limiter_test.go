@@ -0,0 +1,87 @@
+package evotor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	l := newRateLimiter(2, 3)
+	now := l.last
+	for i := range 3 {
+		if delay := l.reserve(now); delay != 0 {
+			t.Fatalf("request %d of the burst waited %v", i+1, delay)
+		}
+	}
+	if delay := l.reserve(now); delay != 500*time.Millisecond {
+		t.Errorf("request past the burst: delay = %v, want 500ms at 2 rps", delay)
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	l := newRateLimiter(2, 3)
+	now := l.last
+	for range 3 {
+		l.reserve(now)
+	}
+
+	// Half a second at 2 rps refills one token.
+	now = now.Add(500 * time.Millisecond)
+	if delay := l.reserve(now); delay != 0 {
+		t.Errorf("after refill: delay = %v, want 0", delay)
+	}
+	if delay := l.reserve(now); delay <= 0 {
+		t.Errorf("refilled token reused: delay = %v", delay)
+	}
+
+	// A long pause refills no more than the burst.
+	now = now.Add(time.Hour)
+	for i := range 3 {
+		if delay := l.reserve(now); delay != 0 {
+			t.Fatalf("request %d after a pause waited %v", i+1, delay)
+		}
+	}
+	if delay := l.reserve(now); delay == 0 {
+		t.Error("pause refilled more than the burst")
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	ctx := context.Background()
+	if err := newRateLimiter(0, 0).Wait(ctx); err != nil {
+		t.Errorf("disabled limiter: %v", err)
+	}
+
+	l := newRateLimiter(1000, 1)
+	started := time.Now()
+	for range 3 {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed < time.Millisecond {
+		t.Errorf("3 requests at 1000 rps with burst 1 took %v, want at least 2ms", elapsed)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	l := newRateLimiter(0.001, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	started := time.Now()
+	if err := l.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Errorf("Wait returned %v after cancel, want promptly", elapsed)
+	}
+}
@@ -0,0 +1,104 @@
+package evotor
+
+import (
+	"context"
+	"strings"
+)
+
+const (
+	MatchedByBarcode       = "barcode"
+	MatchedByArticleNumber = "article_number"
+	MatchedByCode          = "code"
+	MatchedByName          = "name"
+)
+
+// LooksLikeEAN reports whether value is an EAN-13 or EAN-8 code with a valid
+// check digit, i.e. most likely a scanned barcode.
+func LooksLikeEAN(value string) bool {
+	value = strings.TrimSpace(value)
+	if len(value) != 13 && len(value) != 8 {
+		return false
+	}
+	sum := 0
+	for i := len(value) - 2; i >= 0; i-- {
+		d := value[i]
+		if d < '0' || d > '9' {
+			return false
+		}
+		// Weights alternate 3,1,... starting next to the check digit.
+		weight := 1
+		if (len(value)-2-i)%2 == 0 {
+			weight = 3
+		}
+		sum += int(d-'0') * weight
+	}
+	check := value[len(value)-1]
+	if check < '0' || check > '9' {
+		return false
+	}
+	return (10-sum%10)%10 == int(check-'0')
+}
+
+// LookupItems finds products whose barcode, article number or code equals
+// value exactly. Barcodes are compared without leading zeros so EAN-13 and
+// UPC-A forms of the same code match.
+func (c *Client) LookupItems(ctx context.Context, value string, storeID *string) ([]ItemMatch, error) {
+	if !c.hasToken() {
+		return nil, ErrMissingToken
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil, ErrEmptyQuery
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := c.catalogItems(ctx, resolvedStoreID)
+	if err != nil {
+		return nil, err
+	}
+	return lookupItems(items, value), nil
+}
+
+func lookupItems(items []Item, value string) []ItemMatch {
+	value = strings.TrimSpace(value)
+	barcode := normalizeBarcode(value)
+	matches := []ItemMatch{}
+	for _, item := range items {
+		if item.Group {
+			continue
+		}
+		if matchedBy := itemCodeMatch(item, value, barcode); matchedBy != "" {
+			matches = append(matches, ItemMatch{Item: item, MatchedBy: matchedBy, Score: 1})
+		}
+	}
+	return matches
+}
+
+func itemCodeMatch(item Item, value, barcode string) string {
+	for _, candidate := range item.Barcodes {
+		if barcode != "" && normalizeBarcode(candidate) == barcode {
+			return MatchedByBarcode
+		}
+	}
+	if article := strings.TrimSpace(item.ArticleNumber); article != "" && strings.EqualFold(article, value) {
+		return MatchedByArticleNumber
+	}
+	if code := strings.TrimSpace(item.Code); code != "" && code == value {
+		return MatchedByCode
+	}
+	return ""
+}
+
+func normalizeBarcode(value string) string {
+	value = strings.TrimSpace(value)
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return value
+		}
+	}
+	if trimmed := strings.TrimLeft(value, "0"); trimmed != "" {
+		return trimmed
+	}
+	return value
+}
@@ -0,0 +1,26 @@
+package evotor
+
+import "testing"
+
+func TestLooksLikeEAN(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"4006381333931", true},
+		{" 4600682000013 ", true},
+		{"96385074", true},
+		{"4006381333932", false},
+		{"96385075", false},
+		{"400638133393", false},
+		{"40063813339310", false},
+		{"40063813339a1", false},
+		{"4006381333 31", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := LooksLikeEAN(tt.value); got != tt.want {
+			t.Errorf("LooksLikeEAN(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
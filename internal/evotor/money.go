@@ -0,0 +1,103 @@
+package evotor
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Money is an amount in kopecks. Sums over thousands of receipts stay exact,
+// unlike float64 rubles. In JSON it is a number of rubles with two decimals,
+// the way Evotor sends prices and sums.
+type Money int64
+
+// MoneyFromFloat converts rubles to Money, rounding to the nearest kopeck.
+func MoneyFromFloat(rubles float64) Money {
+	return Money(math.Round(rubles * 100))
+}
+
+// ParseMoney reads a decimal number of rubles exactly, rounding half away
+// from zero to kopecks.
+func ParseMoney(value string) (Money, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, fmt.Errorf("invalid money amount %q", value)
+	}
+	r.Mul(r, big.NewRat(100, 1))
+
+	num, den := r.Num(), r.Denom()
+	kopecks, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+		if twice.Cmp(den) >= 0 {
+			kopecks.Add(kopecks, big.NewInt(int64(num.Sign())))
+		}
+	}
+	if !kopecks.IsInt64() {
+		return 0, fmt.Errorf("money amount %q out of range", value)
+	}
+	return Money(kopecks.Int64()), nil
+}
+
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String formats rubles with two decimals, e.g. "1234.50".
+func (m Money) String() string {
+	sign := ""
+	kopecks := int64(m)
+	if kopecks < 0 {
+		sign = "-"
+		kopecks = -kopecks
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, kopecks/100, kopecks%100)
+}
+
+// MulQuantity prices a quantity, e.g. 0.35 kg at m per kg, rounded to the
+// nearest kopeck.
+func (m Money) MulQuantity(quantity float64) Money {
+	return Money(math.Round(float64(m) * quantity))
+}
+
+// Div splits m into n equal parts rounded to the nearest kopeck; zero when n
+// is not positive.
+func (m Money) Div(n int) Money {
+	if n <= 0 {
+		return 0
+	}
+	return Money(math.Round(float64(m) / float64(n)))
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a JSON number, a number in a string or null.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = 0
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		unquoted, err := strconv.Unquote(string(data))
+		if err != nil {
+			return fmt.Errorf("invalid money amount %s", data)
+		}
+		if unquoted == "" {
+			*m = 0
+			return nil
+		}
+		data = []byte(unquoted)
+	}
+	parsed, err := ParseMoney(string(data))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
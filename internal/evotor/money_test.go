@@ -0,0 +1,88 @@
+package evotor
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Money
+	}{
+		{"0", 0},
+		{"12", 1200},
+		{"12.5", 1250},
+		{"1234.56", 123456},
+		{"0.1", 10},
+		{"0.005", 1},
+		{"0.0049", 0},
+		{"-0.005", -1},
+		{"-12.345", -1235},
+		{"1e2", 10000},
+		{"92233720368547758.07", 9223372036854775807},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseMoney(%q) = %d, %v, want %d", tt.value, got, err, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "abc", "1,5", "92233720368547758.08"} {
+		if _, err := ParseMoney(value); err == nil {
+			t.Errorf("ParseMoney(%q): want an error", value)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	for _, tt := range []struct {
+		money Money
+		json  string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{123450, "1234.50"},
+		{-5, "-0.05"},
+		{-123456, "-1234.56"},
+	} {
+		data, err := json.Marshal(tt.money)
+		if err != nil || string(data) != tt.json {
+			t.Errorf("Marshal(%d) = %s, %v, want %s", tt.money, data, err, tt.json)
+		}
+	}
+
+	for _, tt := range []struct {
+		json string
+		want Money
+	}{
+		{"1234.5", 123450},
+		{`"99.99"`, 9999},
+		{`""`, 0},
+		{"null", 0},
+		{"0.1", 10},
+	} {
+		var got Money = 1
+		if err := json.Unmarshal([]byte(tt.json), &got); err != nil || got != tt.want {
+			t.Errorf("Unmarshal(%s) = %d, %v, want %d", tt.json, got, err, tt.want)
+		}
+	}
+
+	for _, data := range []string{`"abc"`, "true", `"1`} {
+		var got Money
+		if err := json.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("Unmarshal(%s): want an error", data)
+		}
+	}
+}
+
+func TestMoneySumStaysExact(t *testing.T) {
+	var sum Money
+	for range 1000 {
+		sum += MoneyFromFloat(0.1)
+	}
+	if sum != 10000 {
+		t.Errorf("1000 × 0.10 = %s, want 100.00", sum)
+	}
+}
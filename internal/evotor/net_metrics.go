@@ -0,0 +1,44 @@
+package evotor
+
+import (
+	"context"
+	"time"
+)
+
+// GetNetSalesMetrics splits a period into gross sales (SELL), customer
+// returns (PAYBACK) and net revenue = gross - returns.
+func (c *Client) GetNetSalesMetrics(ctx context.Context, from, to time.Time, storeID *string) (NetSalesMetrics, error) {
+	if !c.hasToken() {
+		return NetSalesMetrics{}, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return NetSalesMetrics{}, err
+	}
+
+	result := NetSalesMetrics{
+		StoreID:       resolvedStoreID,
+		DocumentTypes: map[DocumentType]int{},
+	}
+	result.From, result.To = periodBounds(from, to)
+
+	for doc, err := range c.documentsSeq(ctx, resolvedStoreID, from, to) {
+		if err != nil {
+			return NetSalesMetrics{}, err
+		}
+		switch {
+		case doc.Type.IsSale():
+			result.GrossSales.Count++
+			result.GrossSales.Sum += doc.Total
+		case doc.Type.IsReturn():
+			result.Returns.Count++
+			result.Returns.Sum += doc.Total
+		default:
+			continue
+		}
+		result.DocumentTypes[doc.Type]++
+	}
+
+	result.NetRevenue = result.GrossSales.Sum - result.Returns.Sum
+	return result, nil
+}
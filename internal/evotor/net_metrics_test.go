@@ -0,0 +1,39 @@
+package evotor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+)
+
+func TestNetSalesMetricsCountsOnlyCustomerReturns(t *testing.T) {
+	documents := `[
+{"id": "sell", "type": "SELL", "store_id": "s1", "close_date": "2025-12-01T10:00:00.000+0000", "body": {"result_sum": 100}},
+{"id": "payback", "type": "PAYBACK", "store_id": "s1", "close_date": "2025-12-01T11:00:00.000+0000", "body": {"result_sum": 30}},
+{"id": "supplier-return", "type": "RETURN", "store_id": "s1", "close_date": "2025-12-01T12:00:00.000+0000", "body": {"result_sum": 50}},
+{"id": "refund", "type": "REFUND", "store_id": "s1", "close_date": "2025-12-01T13:00:00.000+0000", "body": {"result_sum": 10}}
+]`
+	client, _ := newStoreClient(t, map[string]string{"stores/s1/documents.json": documents}, nil)
+
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 1, 23, 59, 59, 0, time.UTC)
+	metrics, err := client.GetNetSalesMetrics(context.Background(), from, to, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if metrics.GrossSales.Count != 1 || metrics.GrossSales.Sum != 10000 {
+		t.Errorf("gross sales = %+v, want 1 receipt of 100.00", metrics.GrossSales)
+	}
+	if metrics.Returns.Count != 1 || metrics.Returns.Sum != 3000 {
+		t.Errorf("returns = %+v, want only the PAYBACK of 30.00", metrics.Returns)
+	}
+	if metrics.NetRevenue != 7000 {
+		t.Errorf("net revenue = %s, want 70.00", metrics.NetRevenue)
+	}
+	if _, ok := metrics.DocumentTypes[evotor.DocumentReturn]; ok {
+		t.Errorf("document types = %v, RETURN is a stock document", metrics.DocumentTypes)
+	}
+}
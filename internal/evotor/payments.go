@@ -0,0 +1,97 @@
+package evotor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// unknownPaymentType collects documents that carry no payments section.
+const unknownPaymentType = "UNKNOWN"
+
+// GetPaymentMetrics splits revenue of a period by payment type (CASH, CARD,
+// ELECTRON, ...). A receipt paid partly by cash and partly by card counts
+// towards both types. With DocumentTypeAll refunds reduce the sums.
+func (c *Client) GetPaymentMetrics(ctx context.Context, from, to time.Time, storeID *string, documentType DocumentType) (PaymentMetrics, error) {
+	if !c.hasToken() {
+		return PaymentMetrics{}, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return PaymentMetrics{}, err
+	}
+	documentType = documentType.orDefault()
+
+	result := PaymentMetrics{
+		StoreID:      resolvedStoreID,
+		DocumentType: documentType,
+		Payments:     []PaymentTypeRow{},
+	}
+	result.From, result.To = periodBounds(from, to)
+
+	rows := map[string]*PaymentTypeRow{}
+	row := func(paymentType string) *PaymentTypeRow {
+		r, ok := rows[paymentType]
+		if !ok {
+			r = &PaymentTypeRow{Type: paymentType}
+			rows[paymentType] = r
+		}
+		return r
+	}
+	for doc, err := range c.documentsSeq(ctx, resolvedStoreID, from, to) {
+		if err != nil {
+			return PaymentMetrics{}, err
+		}
+		if !documentType.matches(doc.Type) {
+			continue
+		}
+		// Session and other service documents carry no money.
+		if len(doc.Body.Payments) == 0 && doc.Total == 0 {
+			continue
+		}
+		// Evotor reports refunds with positive sums. Mixed with sales they
+		// are money given back; a PAYBACK-only report keeps them positive.
+		signed := func(sum Money) Money {
+			if documentType == DocumentTypeAll && doc.Type.IsReturn() {
+				return -sum
+			}
+			return sum
+		}
+		result.Count++
+		if len(doc.Body.Payments) == 0 {
+			r := row(unknownPaymentType)
+			r.Count++
+			r.Sum += signed(doc.Total)
+			result.Revenue += signed(doc.Total)
+			continue
+		}
+		seen := map[string]bool{}
+		for _, payment := range doc.Body.Payments {
+			paymentType := strings.ToUpper(strings.TrimSpace(payment.Type))
+			if paymentType == "" {
+				paymentType = unknownPaymentType
+			}
+			r := row(paymentType)
+			if !seen[paymentType] {
+				r.Count++
+				seen[paymentType] = true
+			}
+			r.Sum += signed(payment.Sum)
+			result.Revenue += signed(payment.Sum)
+		}
+	}
+
+	for _, r := range rows {
+		r.Share = percent(r.Sum.Float64(), result.Revenue.Float64())
+		result.Payments = append(result.Payments, *r)
+	}
+	sort.Slice(result.Payments, func(i, j int) bool {
+		a, b := result.Payments[i], result.Payments[j]
+		if a.Sum != b.Sum {
+			return a.Sum > b.Sum
+		}
+		return a.Type < b.Type
+	})
+	return result, nil
+}
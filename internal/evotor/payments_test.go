@@ -0,0 +1,77 @@
+package evotor_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+)
+
+// paymentDocuments: a is split between cash and card, d pays by card twice,
+// c has no payments section and the session document carries no money.
+const paymentDocuments = `[
+{"id": "a", "type": "SELL", "close_date": "2026-03-01T10:00:00.000+0000", "body": {"result_sum": 300, "payments": [{"type": "CASH", "sum": 100}, {"type": "CARD", "sum": 200}]}},
+{"id": "b", "type": "SELL", "close_date": "2026-03-01T11:00:00.000+0000", "body": {"result_sum": 50, "payments": [{"type": " card ", "sum": 50}]}},
+{"id": "c", "type": "SELL", "close_date": "2026-03-01T12:00:00.000+0000", "body": {"result_sum": 70}},
+{"id": "d", "type": "SELL", "close_date": "2026-03-01T13:00:00.000+0000", "body": {"result_sum": 30, "payments": [{"type": "CARD", "sum": 10}, {"type": "CARD", "sum": 20}]}},
+{"id": "e", "type": "PAYBACK", "close_date": "2026-03-01T14:00:00.000+0000", "body": {"result_sum": 40, "payments": [{"type": "CASH", "sum": 40}]}},
+{"id": "session", "type": "OPEN_SESSION", "close_date": "2026-03-01T15:00:00.000+0000", "body": {}}
+]`
+
+func paymentMetrics(t *testing.T, documentType evotor.DocumentType) evotor.PaymentMetrics {
+	t.Helper()
+	client, _ := newStoreClient(t, map[string]string{"stores/s1/documents.json": paymentDocuments}, nil)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+	got, err := client.GetPaymentMetrics(context.Background(), from, to, nil, documentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func checkPayments(t *testing.T, got evotor.PaymentMetrics, count int, revenue evotor.Money, want []evotor.PaymentTypeRow) {
+	t.Helper()
+	if got.Count != count || got.Revenue != revenue {
+		t.Errorf("total = %d %s, want %d %s", got.Count, got.Revenue, count, revenue)
+	}
+	if len(got.Payments) != len(want) {
+		t.Fatalf("payments = %+v, want %d rows", got.Payments, len(want))
+	}
+	var share float64
+	for i, w := range want {
+		p := got.Payments[i]
+		if p.Type != w.Type || p.Count != w.Count || p.Sum != w.Sum {
+			t.Errorf("row %d = %s %d %s, want %s %d %s", i, p.Type, p.Count, p.Sum, w.Type, w.Count, w.Sum)
+		}
+		share += p.Share
+	}
+	if math.Abs(share-100) > 0.1 {
+		t.Errorf("shares add up to %.2f, want 100", share)
+	}
+}
+
+func TestPaymentMetricsSplitsPayments(t *testing.T) {
+	got := paymentMetrics(t, "")
+	checkPayments(t, got, 4, 45000, []evotor.PaymentTypeRow{
+		{Type: "CARD", Count: 3, Sum: 28000},
+		{Type: "CASH", Count: 1, Sum: 10000},
+		{Type: "UNKNOWN", Count: 1, Sum: 7000},
+	})
+}
+
+func TestPaymentMetricsPaybackSign(t *testing.T) {
+	// A refund report shows the money given back as positive sums.
+	checkPayments(t, paymentMetrics(t, evotor.DocumentPayback), 1, 4000, []evotor.PaymentTypeRow{
+		{Type: "CASH", Count: 1, Sum: 4000},
+	})
+
+	// Mixed with sales the refund reduces the cash it was paid out of.
+	checkPayments(t, paymentMetrics(t, evotor.DocumentTypeAll), 5, 41000, []evotor.PaymentTypeRow{
+		{Type: "CARD", Count: 3, Sum: 28000},
+		{Type: "UNKNOWN", Count: 1, Sum: 7000},
+		{Type: "CASH", Count: 2, Sum: 6000},
+	})
+}
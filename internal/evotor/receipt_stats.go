@@ -0,0 +1,75 @@
+package evotor
+
+import (
+	"math"
+	"slices"
+)
+
+// receiptSample collects per-receipt values so distribution metrics can be
+// computed for one store or merged across stores.
+type receiptSample struct {
+	totals    []Money
+	items     float64
+	positions int
+}
+
+// add records a document as a receipt; documents without positions (session
+// and cash operations) are not receipts and are skipped.
+func (s *receiptSample) add(doc DocumentFull) {
+	if len(doc.Body.Positions) == 0 {
+		return
+	}
+	s.totals = append(s.totals, doc.Total)
+	s.positions += len(doc.Body.Positions)
+	for _, pos := range doc.Body.Positions {
+		s.items += pos.Quantity
+	}
+}
+
+func (s *receiptSample) merge(other receiptSample) {
+	s.totals = append(s.totals, other.totals...)
+	s.items += other.items
+	s.positions += other.positions
+}
+
+func (s receiptSample) stats() ReceiptStats {
+	n := len(s.totals)
+	if n == 0 {
+		return ReceiptStats{}
+	}
+	sorted := make([]Money, n)
+	copy(sorted, s.totals)
+	slices.Sort(sorted)
+
+	var sum Money
+	for _, total := range sorted {
+		sum += total
+	}
+	return ReceiptStats{
+		Receipts:            n,
+		AverageReceipt:      averageReceipt(sum, n),
+		MedianReceipt:       percentile(sorted, 50),
+		P25Receipt:          percentile(sorted, 25),
+		P75Receipt:          percentile(sorted, 75),
+		P90Receipt:          percentile(sorted, 90),
+		MinReceipt:          sorted[0],
+		MaxReceipt:          sorted[n-1],
+		ItemsPerReceipt:     round2(s.items / float64(n)),
+		PositionsPerReceipt: round2(float64(s.positions) / float64(n)),
+	}
+}
+
+// percentile interpolates linearly between the closest ranks of sorted,
+// rounding to the nearest kopeck.
+func percentile(sorted []Money, p float64) Money {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + Money(math.Round(float64(sorted[upper]-sorted[lower])*(rank-float64(lower))))
+}
@@ -0,0 +1,27 @@
+package evotor
+
+import "testing"
+
+func TestPercentile(t *testing.T) {
+	sorted := []Money{100, 200, 300, 400}
+	tests := []struct {
+		name   string
+		sorted []Money
+		p      float64
+		want   Money
+	}{
+		{"empty", nil, 50, 0},
+		{"single", []Money{150}, 90, 150},
+		{"min", sorted, 0, 100},
+		{"max", sorted, 100, 400},
+		{"exact rank", []Money{100, 200, 300}, 50, 200},
+		{"interpolated median", sorted, 50, 250},
+		{"interpolated p90", sorted, 90, 370},
+		{"rounded to kopeck", []Money{0, 1}, 50, 1},
+	}
+	for _, tt := range tests {
+		if got := percentile(tt.sorted, tt.p); got != tt.want {
+			t.Errorf("%s: percentile(%v, %v) = %d, want %d", tt.name, tt.sorted, tt.p, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package evotor
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"simple_answer_llm/internal/config"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	defaultRetryMaxAttempts = 4
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 10 * time.Second
+	defaultRetryAfterMax    = 2 * time.Minute
+)
+
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+	maxDelay    time.Duration
+	// retryAfterMax caps how long a server-requested Retry-After is
+	// honored; it is separate from maxDelay because rate limit windows are
+	// usually much longer than backoff steps.
+	retryAfterMax time.Duration
+}
+
+func newRetryPolicy(cfg config.Config) retryPolicy {
+	policy := retryPolicy{
+		maxAttempts:   cfg.RetryMaxAttempts,
+		baseDelay:     cfg.RetryBaseDelay,
+		maxDelay:      cfg.RetryMaxDelay,
+		retryAfterMax: cfg.RetryAfterMax,
+	}
+	if policy.maxAttempts <= 0 {
+		policy.maxAttempts = defaultRetryMaxAttempts
+	}
+	if policy.baseDelay <= 0 {
+		policy.baseDelay = defaultRetryBaseDelay
+	}
+	if policy.maxDelay < policy.baseDelay {
+		policy.maxDelay = max(defaultRetryMaxDelay, policy.baseDelay)
+	}
+	if policy.retryAfterMax <= 0 {
+		policy.retryAfterMax = defaultRetryAfterMax
+	}
+	policy.retryAfterMax = max(policy.retryAfterMax, policy.maxDelay)
+	return policy
+}
+
+// retryable reports whether a failed attempt may succeed when repeated:
+// transport errors, 429 and 5xx responses.
+func (p retryPolicy) retryable(ctx context.Context, resp *resty.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	if resp == nil {
+		return false
+	}
+	status := resp.StatusCode()
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// delay honors Retry-After when the server sent one and otherwise uses
+// exponential backoff with full jitter, capped at maxDelay. It reports false
+// when Retry-After asks for more than retryAfterMax: retrying sooner would
+// only be refused again.
+func (p retryPolicy) delay(attempt int, resp *resty.Response, now time.Time) (time.Duration, bool) {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header().Get("Retry-After"), now); ok {
+			return wait, wait <= p.retryAfterMax
+		}
+	}
+
+	backoff := p.baseDelay << min(attempt-1, 30)
+	if backoff <= 0 || backoff > p.maxDelay {
+		backoff = p.maxDelay
+	}
+	return time.Duration(rand.Int64N(int64(backoff)) + 1), true
+}
+
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,57 @@
+package evotor
+
+import (
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/config"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-5", 0, true},
+		{"Wed, 10 Dec 2025 12:00:30 GMT", 30 * time.Second, true},
+		{"Wed, 10 Dec 2025 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.value, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetryDelayBackoffCapped(t *testing.T) {
+	policy := retryPolicy{maxAttempts: 10, baseDelay: 100 * time.Millisecond, maxDelay: time.Second}
+	for attempt := 1; attempt <= 40; attempt++ {
+		delay, ok := policy.delay(attempt, nil, time.Now())
+		if !ok || delay <= 0 || delay > policy.maxDelay {
+			t.Fatalf("attempt %d: delay = %v, %v; want (0, %v]", attempt, delay, ok, policy.maxDelay)
+		}
+	}
+}
+
+func TestNewRetryPolicyRetryAfterMax(t *testing.T) {
+	tests := []struct {
+		cfg  config.Config
+		want time.Duration
+	}{
+		{config.Config{}, defaultRetryAfterMax},
+		{config.Config{RetryAfterMax: 5 * time.Minute}, 5 * time.Minute},
+		// Never below the backoff cap.
+		{config.Config{RetryMaxDelay: 30 * time.Second, RetryAfterMax: time.Second}, 30 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := newRetryPolicy(tt.cfg).retryAfterMax; got != tt.want {
+			t.Errorf("newRetryPolicy(%+v).retryAfterMax = %v, want %v", tt.cfg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package evotor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"simple_answer_llm/internal/match"
+)
+
+// stockMaxAge bounds how old the catalog copy may be when answering stock
+// questions; balances change with every receipt, unlike names and prices.
+const stockMaxAge = time.Minute
+
+type StockOptions struct {
+	// Query matches product names; empty keeps every product.
+	Query string
+	// Category keeps only products of this group (ID or name) and its subgroups.
+	Category string
+	// Status filters by balance: "all" (default), "in_stock", "out_of_stock"
+	// (zero or negative) or "negative".
+	Status string
+	// Limit caps the returned items; totals cover every matched product.
+	Limit int
+}
+
+// GetStock reports current balances from the product catalog. Products with
+// zero or negative quantity are included unless Status filters them out.
+func (c *Client) GetStock(ctx context.Context, storeID *string, opts StockOptions) (StockReport, error) {
+	if !c.hasToken() {
+		return StockReport{}, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return StockReport{}, err
+	}
+
+	status := strings.ToLower(strings.TrimSpace(opts.Status))
+	switch status {
+	case "":
+		status = "all"
+	case "all", "in_stock", "out_of_stock", "negative":
+	default:
+		return StockReport{}, fmt.Errorf("unsupported stock status %q: use all, in_stock, out_of_stock or negative", opts.Status)
+	}
+
+	snapshot, err := c.catalogSnapshotWithin(ctx, resolvedStoreID, stockMaxAge)
+	if err != nil {
+		return StockReport{}, err
+	}
+	categories := newCategoryIndex(snapshot)
+	var group ProductGroup
+	if category := strings.TrimSpace(opts.Category); category != "" {
+		group, err = categories.resolve(category)
+		if err != nil {
+			return StockReport{}, err
+		}
+	}
+
+	result := StockReport{
+		StoreID:  resolvedStoreID,
+		Query:    strings.TrimSpace(opts.Query),
+		Status:   status,
+		SyncedAt: snapshot.SyncedAt,
+		Items:    []StockItem{},
+	}
+	if group.ID != "" {
+		result.Category = categories.path(group.ID)
+	}
+
+	matcher := match.New(result.Query)
+	for _, item := range snapshot.Items {
+		if item.Group || !matcher.Matches(item.Name) {
+			continue
+		}
+		if group.ID != "" && !categories.contains(group.ID, item.ID) {
+			continue
+		}
+		if !stockStatusMatches(status, item.Quantity) {
+			continue
+		}
+
+		costValue := item.CostPrice.MulQuantity(item.Quantity)
+		result.Total++
+		switch {
+		case item.Quantity > 0:
+			result.InStock++
+			result.CostValue += costValue
+			result.RetailValue += item.Price.MulQuantity(item.Quantity)
+		case item.Quantity == 0:
+			result.Zero++
+		default:
+			result.Negative++
+		}
+		result.Items = append(result.Items, StockItem{
+			ProductID:   item.ID,
+			Name:        item.Name,
+			Category:    categories.path(item.ParentID),
+			Quantity:    item.Quantity,
+			MeasureName: item.MeasureName,
+			Price:       item.Price,
+			CostPrice:   item.CostPrice,
+			CostValue:   costValue,
+		})
+	}
+
+	// Shortages first: they are what the question is usually about.
+	sort.Slice(result.Items, func(i, j int) bool {
+		a, b := result.Items[i], result.Items[j]
+		if (a.Quantity <= 0) != (b.Quantity <= 0) {
+			return a.Quantity <= 0
+		}
+		if a.Quantity <= 0 && a.Quantity != b.Quantity {
+			return a.Quantity < b.Quantity
+		}
+		return a.Name < b.Name
+	})
+	if opts.Limit > 0 && len(result.Items) > opts.Limit {
+		result.Items = result.Items[:opts.Limit]
+	}
+	return result, nil
+}
+
+func stockStatusMatches(status string, quantity float64) bool {
+	switch status {
+	case "in_stock":
+		return quantity > 0
+	case "out_of_stock":
+		return quantity <= 0
+	case "negative":
+		return quantity < 0
+	default:
+		return true
+	}
+}
@@ -0,0 +1,168 @@
+package evotor_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"simple_answer_llm/internal/config"
+	"simple_answer_llm/internal/evotor"
+	"simple_answer_llm/internal/evotor/evotortest"
+)
+
+// stockProducts extends testProducts with a deeper shortage and a product
+// sold by weight.
+var stockProducts = strings.Replace(testProducts, "\n]", `,
+{"id": "p-salt", "name": "Соль", "parent_id": "g-food", "price": 20, "cost_price": 10, "quantity": -5, "measure_name": "шт"},
+{"id": "p-apple", "name": "Яблоки", "parent_id": "g-food", "price": 80, "cost_price": 50, "quantity": 3.5, "measure_name": "кг"}
+]`, 1)
+
+func stockNames(report evotor.StockReport) string {
+	names := make([]string, 0, len(report.Items))
+	for _, item := range report.Items {
+		names = append(names, item.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func TestStockStatusesAndOrder(t *testing.T) {
+	client, _ := newStoreClient(t, map[string]string{
+		"stores/s1/products.json":       stockProducts,
+		"stores/s1/product-groups.json": testGroups,
+	}, nil)
+	ctx := context.Background()
+
+	all, err := client.GetStock(ctx, nil, evotor.StockOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Shortages come first, deepest first, then the rest by name.
+	if got, want := stockNames(all), "Соль, Лак для волос, Хлеб, Молоко 3,2%, Яблоки"; got != want {
+		t.Errorf("items = %s, want %s", got, want)
+	}
+	if all.Status != "all" || all.Total != 5 || all.InStock != 2 || all.Zero != 1 || all.Negative != 2 {
+		t.Errorf("report = %s total %d in_stock %d zero %d negative %d, want all 5 2 1 2", all.Status, all.Total, all.InStock, all.Zero, all.Negative)
+	}
+	// Only positive balances are valued: milk 10 and apples 3.5 kg.
+	if all.CostValue != 87500 || all.RetailValue != 128000 {
+		t.Errorf("values = %s cost, %s retail; want 875.00 and 1280.00", all.CostValue, all.RetailValue)
+	}
+
+	for status, want := range map[string]string{
+		"in_stock":     "Молоко 3,2%, Яблоки",
+		"out_of_stock": "Соль, Лак для волос, Хлеб",
+		"NEGATIVE":     "Соль, Лак для волос",
+	} {
+		report, err := client.GetStock(ctx, nil, evotor.StockOptions{Status: status})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := stockNames(report); got != want {
+			t.Errorf("status %s: items = %s, want %s", status, got, want)
+		}
+	}
+	if _, err := client.GetStock(ctx, nil, evotor.StockOptions{Status: "low"}); err == nil {
+		t.Error("status low: want an error")
+	}
+}
+
+func TestStockCategoryAndLimit(t *testing.T) {
+	client, _ := newStoreClient(t, map[string]string{
+		"stores/s1/products.json":       stockProducts,
+		"stores/s1/product-groups.json": testGroups,
+	}, nil)
+
+	report, err := client.GetStock(context.Background(), nil, evotor.StockOptions{Category: "продукты", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Category != "Продукты" || report.Total != 4 {
+		t.Errorf("category = %q, total = %d; want Продукты and 4 products including Молочные", report.Category, report.Total)
+	}
+	if got, want := stockNames(report), "Соль, Хлеб"; got != want {
+		t.Errorf("items = %s, want %s", got, want)
+	}
+}
+
+func TestStockRefreshesOldCatalog(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := func(c *config.Config) { c.CacheDir = dir }
+	ctx := context.Background()
+
+	warm, _ := newStoreClient(t, map[string]string{
+		"stores/s1/products.json":       testProducts,
+		"stores/s1/product-groups.json": testGroups,
+	}, cacheDir)
+	if _, err := warm.GetCategoryTree(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Age the cached copy past stockMaxAge but well within CatalogTTL, and
+	// drop full_synced_at so the refresh reloads every product.
+	path := filepath.Join(dir, "catalog", "s1.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var snapshot map[string]any
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	snapshot["synced_at"] = time.Now().Add(-2 * time.Minute)
+	delete(snapshot, "full_synced_at")
+	if data, err = json.Marshal(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The API now reports milk sold out.
+	fixtures, err := evotortest.LoadFixtures(fstest.MapFS{
+		"stores.json":                   {Data: []byte(`[{"id": "s1", "name": "Тестовый"}]`)},
+		"stores/s1/products.json":       {Data: []byte(strings.Replace(testProducts, `"quantity": 10`, `"quantity": 0`, 1))},
+		"stores/s1/product-groups.json": {Data: []byte(testGroups)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, fake := newFixturesClient(t, fixtures, evotortest.Options{}, func(c *config.Config) {
+		c.EvotorStoreID = "s1"
+		c.StoreTimezone = "UTC"
+		cacheDir(c)
+	})
+
+	if _, err := client.GetCategoryTree(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.Requests(); got != 0 {
+		t.Fatalf("requests = %d, want the category tree served from cache", got)
+	}
+
+	report, err := client.GetStock(ctx, nil, evotor.StockOptions{Query: "молоко"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Items) != 1 || report.Items[0].Quantity != 0 {
+		t.Fatalf("items = %+v, want milk with the refreshed balance", report.Items)
+	}
+	if time.Since(report.SyncedAt) > time.Minute {
+		t.Errorf("synced_at = %s, want a fresh sync", report.SyncedAt)
+	}
+	requests := fake.Requests()
+	if requests == 0 {
+		t.Fatal("no requests: want the old catalog refreshed")
+	}
+
+	if _, err := client.GetStock(ctx, nil, evotor.StockOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.Requests(); got != requests {
+		t.Errorf("requests = %d, want %d: a fresh catalog is reused", got, requests)
+	}
+}
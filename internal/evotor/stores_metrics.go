@@ -0,0 +1,114 @@
+package evotor
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// GetSalesMetricsByStores computes sales metrics for several stores
+// concurrently. With no storeIDs every store from ListStores is used. A store
+// that fails is reported in its row and left out of the total; the call fails
+// only when every store does. from and to are wall-clock times applied in
+// each store's own timezone, so "yesterday" is each store's yesterday.
+func (c *Client) GetSalesMetricsByStores(ctx context.Context, from, to time.Time, storeIDs []string, documentType DocumentType) (StoresSalesMetrics, error) {
+	if !c.hasToken() {
+		return StoresSalesMetrics{}, ErrMissingToken
+	}
+
+	stores, err := c.ListStores(ctx)
+	if err != nil {
+		if len(storeIDs) == 0 {
+			return StoresSalesMetrics{}, err
+		}
+		c.logger.Warn("store names unavailable", zap.Error(err))
+	}
+	names := make(map[string]string, len(stores))
+	for _, store := range stores {
+		names[store.ID] = store.Name
+	}
+
+	ids := make([]string, 0, len(storeIDs))
+	for _, storeID := range storeIDs {
+		if storeID = strings.TrimSpace(storeID); storeID != "" {
+			ids = append(ids, storeID)
+		}
+	}
+	if len(ids) == 0 {
+		for _, store := range stores {
+			ids = append(ids, store.ID)
+		}
+	}
+
+	rows := make([]StoreSalesMetrics, len(ids))
+	samples := make([]receiptSample, len(ids))
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, c.fetchConcurrency)
+	var wg sync.WaitGroup
+	for i, storeID := range ids {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var metrics SalesMetrics
+			var sample receiptSample
+			loc, err := c.storeLocation(ctx, storeID)
+			if err == nil {
+				metrics, sample, err = c.salesMetrics(ctx, storeID, InLocation(from, loc), InLocation(to, loc), documentType)
+			}
+			samples[i] = sample
+			if err != nil {
+				metrics = SalesMetrics{StoreID: storeID}
+				errs[i] = err
+			}
+			rows[i] = StoreSalesMetrics{
+				StoreName:    names[storeID],
+				SalesMetrics: metrics,
+			}
+			if err != nil {
+				rows[i].Error = err.Error()
+			}
+		})
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return StoresSalesMetrics{}, err
+	}
+
+	result := StoresSalesMetrics{
+		Stores: rows,
+		Total: SalesMetrics{
+			DocumentTypes: map[DocumentType]int{},
+		},
+	}
+	result.Total.From, result.Total.To = periodBounds(from, to)
+
+	var firstErr error
+	failed := 0
+	var receipts receiptSample
+	for i, row := range rows {
+		if errs[i] != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			continue
+		}
+		result.Total.Count += row.Count
+		result.Total.TotalSum += row.TotalSum
+		for docType, count := range row.DocumentTypes {
+			result.Total.DocumentTypes[docType] += count
+		}
+		receipts.merge(samples[i])
+	}
+	result.Total.ReceiptStats = receipts.stats()
+	if len(rows) > 0 && failed == len(rows) {
+		return StoresSalesMetrics{}, firstErr
+	}
+
+	return result, nil
+}
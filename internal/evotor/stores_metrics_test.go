@@ -0,0 +1,81 @@
+package evotor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+	"simple_answer_llm/internal/evotor/evotortest"
+)
+
+func TestSalesMetricsByStoresTotals(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t, evotortest.Options{}, nil)
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	result, err := client.GetSalesMetricsByStores(ctx, from, to, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Stores) != 2 {
+		t.Fatalf("stores = %d, want both fixture stores", len(result.Stores))
+	}
+	var count int
+	var sum evotor.Money
+	for _, row := range result.Stores {
+		if row.Error != "" || row.StoreName == "" || row.Count == 0 {
+			t.Errorf("row %+v, want a named store with sales", row)
+		}
+		count += row.Count
+		sum += row.TotalSum
+	}
+	if result.Total.Count != count || result.Total.TotalSum != sum {
+		t.Errorf("total = %d %s, want the sum of rows %d %s", result.Total.Count, result.Total.TotalSum, count, sum)
+	}
+	if result.Total.Receipts != count {
+		t.Errorf("total receipts = %d, want %d", result.Total.Receipts, count)
+	}
+}
+
+func TestSalesMetricsByStoresPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestClient(t, evotortest.Options{ForbiddenStores: []string{testOtherStore}}, nil)
+	from := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	result, err := client.GetSalesMetricsByStores(ctx, from, to, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Stores) != 2 {
+		t.Fatalf("stores = %d, want both rows", len(result.Stores))
+	}
+	ok, failed := result.Stores[0], result.Stores[1]
+	if ok.StoreID != testStoreID || ok.Error != "" {
+		t.Fatalf("first row = %+v, want %s without error", ok, testStoreID)
+	}
+	if failed.StoreID != testOtherStore || failed.Error == "" || failed.Count != 0 {
+		t.Errorf("second row = %+v, want %s with an error and no sales", failed, testOtherStore)
+	}
+
+	storeID := testStoreID
+	loc, err := client.StoreLocation(ctx, &storeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := client.GetSalesMetrics(ctx, evotor.InLocation(from, loc), evotor.InLocation(to, loc), &storeID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok.Count != single.Count || result.Total.Count != single.Count || result.Total.TotalSum != single.TotalSum {
+		t.Errorf("total = %d %s, want only the working store: %d %s", result.Total.Count, result.Total.TotalSum, single.Count, single.TotalSum)
+	}
+
+	_, err = client.GetSalesMetricsByStores(ctx, from, to, []string{testOtherStore}, "")
+	if !errors.Is(err, evotor.ErrUnauthorized) {
+		t.Errorf("only failing stores: err = %v, want ErrUnauthorized", err)
+	}
+}
@@ -0,0 +1,41 @@
+package evotor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const apiTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+var timeLayouts = []string{
+	apiTimeLayout,
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339Nano,
+	time.RFC3339,
+}
+
+func ParseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty time value")
+	}
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported time format: %q", value)
+}
+
+// periodBounds formats a report period; a zero bound stays empty.
+func periodBounds(from, to time.Time) (string, string) {
+	var fromStr, toStr string
+	if !from.IsZero() {
+		fromStr = from.Format(time.RFC3339)
+	}
+	if !to.IsZero() {
+		toStr = to.Format(time.RFC3339)
+	}
+	return fromStr, toStr
+}
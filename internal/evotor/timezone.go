@@ -0,0 +1,150 @@
+package evotor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// storeTimezones resolves the timezone of each store. Configured zones win;
+// otherwise the offset reported by the store's cash registers is used, and
+// fallback when the store has none.
+type storeTimezones struct {
+	fallback *time.Location
+	byStore  map[string]*time.Location
+	// err reports an unusable STORE_TIMEZONE value on first use.
+	err error
+
+	mu       sync.Mutex
+	resolved map[string]*time.Location
+}
+
+// newStoreTimezones parses STORE_TIMEZONE: an IANA zone for every store
+// ("Asia/Vladivostok") and/or per-store zones ("<store_id>=Europe/Moscow"),
+// separated by commas.
+func newStoreTimezones(value string) *storeTimezones {
+	zones := &storeTimezones{
+		fallback: time.Local,
+		byStore:  map[string]*time.Location{},
+		resolved: map[string]*time.Location{},
+	}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		storeID, name, perStore := strings.Cut(entry, "=")
+		if !perStore {
+			name = storeID
+		}
+		loc, err := time.LoadLocation(strings.TrimSpace(name))
+		if err != nil {
+			zones.err = fmt.Errorf("invalid store timezone %q: %w", entry, err)
+			continue
+		}
+		if perStore {
+			zones.byStore[strings.TrimSpace(storeID)] = loc
+		} else {
+			zones.fallback = loc
+		}
+	}
+	return zones
+}
+
+// DefaultLocation is the timezone used when no store is selected.
+func (c *Client) DefaultLocation() *time.Location {
+	return c.timezones.fallback
+}
+
+// StoreLocation returns the timezone the store works in, so that "yesterday"
+// and daily buckets follow the store's calendar rather than this machine's.
+func (c *Client) StoreLocation(ctx context.Context, storeID *string) (*time.Location, error) {
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return nil, err
+	}
+	return c.storeLocation(ctx, resolvedStoreID)
+}
+
+func (c *Client) storeLocation(ctx context.Context, storeID string) (*time.Location, error) {
+	zones := c.timezones
+	if zones.err != nil {
+		return nil, zones.err
+	}
+	if loc, ok := zones.byStore[storeID]; ok {
+		return loc, nil
+	}
+
+	zones.mu.Lock()
+	loc, ok := zones.resolved[storeID]
+	zones.mu.Unlock()
+	if ok {
+		return loc, nil
+	}
+	if !c.hasToken() {
+		return zones.fallback, nil
+	}
+
+	devices, err := c.storeDevices(ctx, storeID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		// Not cached: the next call retries.
+		c.logger.Warn("store timezone unavailable, using default", zap.String("store_id", storeID), zap.Error(err))
+		return zones.fallback, nil
+	}
+	loc = devicesLocation(devices)
+	if loc == nil {
+		loc = zones.fallback
+	}
+
+	zones.mu.Lock()
+	zones.resolved[storeID] = loc
+	zones.mu.Unlock()
+	return loc, nil
+}
+
+// devicesLocation picks the UTC offset most of the store's cash registers
+// report; nil when none does.
+func devicesLocation(devices []Device) *time.Location {
+	counts := map[int64]int{}
+	var offset int64
+	for _, device := range devices {
+		if device.TimezoneOffset == 0 {
+			continue
+		}
+		counts[device.TimezoneOffset]++
+		if c := counts[device.TimezoneOffset]; c > counts[offset] || (c == counts[offset] && device.TimezoneOffset < offset) {
+			offset = device.TimezoneOffset
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	seconds := int(offset / int64(time.Second/time.Millisecond))
+	return time.FixedZone(offsetName(seconds), seconds)
+}
+
+// offsetName formats an offset like "UTC+10:00".
+func offsetName(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// InLocation keeps the wall clock of t and moves it to loc: 00:00 stays
+// 00:00 whatever offset t was written with.
+func InLocation(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() || loc == nil {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
@@ -0,0 +1,93 @@
+package evotor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewStoreTimezones(t *testing.T) {
+	zones := newStoreTimezones("Asia/Vladivostok, s1=Europe/Moscow ,s2 = Asia/Yekaterinburg")
+	if zones.err != nil {
+		t.Fatal(zones.err)
+	}
+	if got := zones.fallback.String(); got != "Asia/Vladivostok" {
+		t.Errorf("fallback = %s, want Asia/Vladivostok", got)
+	}
+	for storeID, want := range map[string]string{"s1": "Europe/Moscow", "s2": "Asia/Yekaterinburg"} {
+		if loc := zones.byStore[storeID]; loc == nil || loc.String() != want {
+			t.Errorf("store %s zone = %v, want %s", storeID, loc, want)
+		}
+	}
+
+	if zones := newStoreTimezones(""); zones.err != nil || zones.fallback != time.Local || len(zones.byStore) != 0 {
+		t.Errorf("empty value: fallback %v, stores %v, err %v; want time.Local", zones.fallback, zones.byStore, zones.err)
+	}
+
+	zones = newStoreTimezones("Europe/Moscow,s1=Mars/Olympus")
+	if zones.err == nil {
+		t.Error("invalid per-store zone: want an error")
+	}
+	if zones.fallback.String() != "Europe/Moscow" {
+		t.Errorf("valid entries should still apply, fallback = %s", zones.fallback)
+	}
+}
+
+func TestStoreLocationReportsInvalidZone(t *testing.T) {
+	c := &Client{timezones: newStoreTimezones("Nowhere/City")}
+	if _, err := c.storeLocation(t.Context(), "s1"); err == nil {
+		t.Error("want the STORE_TIMEZONE error")
+	}
+}
+
+func TestDevicesLocation(t *testing.T) {
+	const (
+		vladivostok = 10 * 3600 * 1000
+		moscow      = 3 * 3600 * 1000
+		india       = 5*3600*1000 + 30*60*1000
+	)
+	tests := []struct {
+		name    string
+		devices []Device
+		want    string
+		offset  int
+	}{
+		{"majority", []Device{{TimezoneOffset: moscow}, {TimezoneOffset: vladivostok}, {TimezoneOffset: vladivostok}}, "UTC+10:00", 10 * 3600},
+		{"tie takes the smaller offset", []Device{{TimezoneOffset: vladivostok}, {TimezoneOffset: moscow}}, "UTC+03:00", 3 * 3600},
+		{"unset offsets ignored", []Device{{}, {}, {TimezoneOffset: india}}, "UTC+05:30", 5*3600 + 30*60},
+		{"negative", []Device{{TimezoneOffset: -4 * 3600 * 1000}}, "UTC-04:00", -4 * 3600},
+	}
+	for _, tt := range tests {
+		loc := devicesLocation(tt.devices)
+		if loc == nil {
+			t.Errorf("%s: no location", tt.name)
+			continue
+		}
+		name, offset := time.Date(2026, 1, 1, 0, 0, 0, 0, loc).Zone()
+		if name != tt.want || offset != tt.offset {
+			t.Errorf("%s: zone = %s %d, want %s %d", tt.name, name, offset, tt.want, tt.offset)
+		}
+	}
+
+	if loc := devicesLocation(nil); loc != nil {
+		t.Errorf("no devices: %v, want nil", loc)
+	}
+	if loc := devicesLocation([]Device{{}, {}}); loc != nil {
+		t.Errorf("devices without offsets: %v, want nil", loc)
+	}
+}
+
+func TestInLocation(t *testing.T) {
+	vladivostok := time.FixedZone("UTC+10:00", 10*3600)
+	midnightUTC := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	got := InLocation(midnightUTC, vladivostok)
+	if want := time.Date(2026, 3, 1, 0, 0, 0, 0, vladivostok); !got.Equal(want) || got.Location() != vladivostok {
+		t.Errorf("InLocation = %v, want %v", got, want)
+	}
+	if got := InLocation(midnightUTC, nil); !got.Equal(midnightUTC) {
+		t.Errorf("nil location: %v, want t unchanged", got)
+	}
+	if got := InLocation(time.Time{}, vladivostok); !got.IsZero() {
+		t.Errorf("zero time: %v, want zero", got)
+	}
+}
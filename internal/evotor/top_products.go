@@ -0,0 +1,166 @@
+package evotor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultTopProductsLimit = 10
+
+type TopProductsOptions struct {
+	// By ranks products by "revenue" (default) or "quantity".
+	By    string
+	Limit int
+	// DocumentType filters documents; see DocumentType.
+	DocumentType DocumentType
+	// Category keeps only products of this group (ID or name) and its subgroups.
+	Category string
+	// GroupBy aggregates by "product" (default) or "category": top-level
+	// groups, or the direct subgroups of Category when it is set.
+	GroupBy string
+}
+
+func (c *Client) GetTopProducts(ctx context.Context, from, to time.Time, storeID *string, opts TopProductsOptions) (TopProducts, error) {
+	if !c.hasToken() {
+		return TopProducts{}, ErrMissingToken
+	}
+	resolvedStoreID, err := c.resolveStoreID(storeID)
+	if err != nil {
+		return TopProducts{}, err
+	}
+
+	by := strings.ToLower(strings.TrimSpace(opts.By))
+	switch by {
+	case "":
+		by = "revenue"
+	case "revenue", "quantity":
+	default:
+		return TopProducts{}, fmt.Errorf("unsupported ranking %q: use revenue or quantity", opts.By)
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultTopProductsLimit
+	}
+	documentType := opts.DocumentType.orDefault()
+	groupBy := strings.ToLower(strings.TrimSpace(opts.GroupBy))
+	switch groupBy {
+	case "":
+		groupBy = "product"
+	case "product", "category":
+	default:
+		return TopProducts{}, fmt.Errorf("unsupported grouping %q: use product or category", opts.GroupBy)
+	}
+	categories, group, err := c.categoryFilter(ctx, resolvedStoreID, opts.Category, groupBy == "category")
+	if err != nil {
+		return TopProducts{}, err
+	}
+
+	stats := map[string]*ProductStat{}
+	seenIn := map[string]string{}
+	for doc, err := range c.documentsSeq(ctx, resolvedStoreID, from, to) {
+		if err != nil {
+			return TopProducts{}, err
+		}
+		if !documentType.matches(doc.Type) {
+			continue
+		}
+		for _, pos := range doc.Body.Positions {
+			if group.ID != "" && !categories.contains(group.ID, pos.ProductID) {
+				continue
+			}
+			key := positionKey(pos)
+			if groupBy == "category" {
+				key = "category:" + categories.childUnder(group.ID, pos.ProductID)
+			}
+			if key == "" {
+				continue
+			}
+			stat, ok := stats[key]
+			if !ok {
+				stat = newProductStat(pos, categories, group.ID, groupBy)
+				stats[key] = stat
+			}
+			stat.Quantity += pos.Quantity
+			stat.Revenue += pos.Revenue()
+			if seenIn[key] != doc.ID {
+				stat.Receipts++
+				seenIn[key] = doc.ID
+			}
+		}
+	}
+
+	products := make([]ProductStat, 0, len(stats))
+	for _, stat := range stats {
+		products = append(products, *stat)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		a, b := products[i], products[j]
+		if by == "quantity" && a.Quantity != b.Quantity {
+			return a.Quantity > b.Quantity
+		}
+		if a.Revenue != b.Revenue {
+			return a.Revenue > b.Revenue
+		}
+		return a.Name < b.Name
+	})
+
+	result := TopProducts{
+		StoreID:      resolvedStoreID,
+		By:           by,
+		GroupBy:      groupBy,
+		DocumentType: documentType,
+		Total:        len(products),
+	}
+	if group.ID != "" {
+		result.Category = categories.path(group.ID)
+	}
+	result.From, result.To = periodBounds(from, to)
+	if len(products) > limit {
+		products = products[:limit]
+	}
+	result.Products = products
+	return result, nil
+}
+
+func newProductStat(pos DocumentPosition, categories *categoryIndex, rootID, groupBy string) *ProductStat {
+	if categories == nil {
+		return &ProductStat{ProductID: pos.ProductID, Name: positionName(pos)}
+	}
+	if groupBy == "category" {
+		categoryID := categories.childUnder(rootID, pos.ProductID)
+		name := uncategorizedName
+		if categoryID != "" {
+			name = categories.groups[categoryID].Name
+		}
+		return &ProductStat{CategoryID: categoryID, Category: categories.path(categoryID), Name: name}
+	}
+	categoryID := categories.parentOf[pos.ProductID]
+	return &ProductStat{
+		ProductID:  pos.ProductID,
+		CategoryID: categoryID,
+		Category:   categories.path(categoryID),
+		Name:       positionName(pos),
+	}
+}
+
+// positionKey groups positions by product_id, falling back to the
+// normalized name when the ID is absent.
+func positionKey(pos DocumentPosition) string {
+	if id := strings.TrimSpace(pos.ProductID); id != "" {
+		return "id:" + id
+	}
+	if name := strings.ToLower(strings.TrimSpace(positionName(pos))); name != "" {
+		return "name:" + name
+	}
+	return ""
+}
+
+func positionName(pos DocumentPosition) string {
+	if name := strings.TrimSpace(pos.ProductName); name != "" {
+		return name
+	}
+	return strings.TrimSpace(pos.Name)
+}
@@ -0,0 +1,123 @@
+package evotor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple_answer_llm/internal/evotor"
+)
+
+// topProductsDocuments sell milk under two names, a bag without product_id
+// spelled two ways, and refund the lak spray.
+const topProductsDocuments = `[
+{"id": "a", "type": "SELL", "close_date": "2026-03-01T10:00:00.000+0000", "body": {"positions": [
+  {"product_id": "p-milk", "product_name": "Молоко 3,2%", "quantity": 2, "result_sum": 200},
+  {"product_id": "p-lak", "product_name": "Лак для волос", "quantity": 1, "result_sum": 250}]}},
+{"id": "b", "type": "SELL", "close_date": "2026-03-01T11:00:00.000+0000", "body": {"positions": [
+  {"product_id": "p-milk", "product_name": "Молоко (акция)", "quantity": 1, "result_sum": 90},
+  {"name": "Пакет", "quantity": 5, "result_sum": 25},
+  {"name": " пакет ", "quantity": 3, "result_sum": 15}]}},
+{"id": "payback", "type": "PAYBACK", "close_date": "2026-03-01T12:00:00.000+0000", "body": {"positions": [
+  {"product_id": "p-lak", "product_name": "Лак для волос", "quantity": 1, "result_sum": 250}]}},
+{"id": "c", "type": "SELL", "close_date": "2026-03-01T13:00:00.000+0000", "body": {"positions": [
+  {"product_id": "p-bread", "product_name": "Хлеб", "quantity": 10, "result_sum": 150}]}}
+]`
+
+type wantProduct struct {
+	name     string
+	quantity float64
+	revenue  evotor.Money
+	receipts int
+}
+
+func topProducts(t *testing.T, opts evotor.TopProductsOptions) evotor.TopProducts {
+	t.Helper()
+	client, _ := newStoreClient(t, map[string]string{
+		"stores/s1/documents.json":      topProductsDocuments,
+		"stores/s1/products.json":       testProducts,
+		"stores/s1/product-groups.json": testGroups,
+	}, nil)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+	got, err := client.GetTopProducts(context.Background(), from, to, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return got
+}
+
+func checkProducts(t *testing.T, got evotor.TopProducts, want []wantProduct) {
+	t.Helper()
+	if len(got.Products) != len(want) {
+		t.Fatalf("products = %+v, want %d", got.Products, len(want))
+	}
+	for i, w := range want {
+		p := got.Products[i]
+		if p.Name != w.name || p.Quantity != w.quantity || p.Revenue != w.revenue || p.Receipts != w.receipts {
+			t.Errorf("product %d = %s %v %s %d, want %s %v %s %d", i, p.Name, p.Quantity, p.Revenue, p.Receipts, w.name, w.quantity, w.revenue, w.receipts)
+		}
+	}
+}
+
+func TestTopProductsByRevenue(t *testing.T) {
+	got := topProducts(t, evotor.TopProductsOptions{})
+	if got.By != "revenue" || got.GroupBy != "product" || got.Total != 4 {
+		t.Errorf("by = %s, group_by = %s, total = %d; want revenue, product, 4", got.By, got.GroupBy, got.Total)
+	}
+	// Milk keeps the first name it was seen under; both bag spellings
+	// share the normalized name. The refund is not a sale.
+	checkProducts(t, got, []wantProduct{
+		{"Молоко 3,2%", 3, 29000, 2},
+		{"Лак для волос", 1, 25000, 1},
+		{"Хлеб", 10, 15000, 1},
+		{"Пакет", 8, 4000, 1},
+	})
+	if got.Products[0].ProductID != "p-milk" {
+		t.Errorf("milk product_id = %q, want p-milk", got.Products[0].ProductID)
+	}
+	if got.Products[3].ProductID != "" {
+		t.Errorf("bag product_id = %q, want none", got.Products[3].ProductID)
+	}
+}
+
+func TestTopProductsByQuantity(t *testing.T) {
+	got := topProducts(t, evotor.TopProductsOptions{By: "quantity", Limit: 3})
+	if got.Total != 4 {
+		t.Errorf("total = %d, want 4 before the limit", got.Total)
+	}
+	checkProducts(t, got, []wantProduct{
+		{"Хлеб", 10, 15000, 1},
+		{"Пакет", 8, 4000, 1},
+		{"Молоко 3,2%", 3, 29000, 2},
+	})
+}
+
+func TestTopProductsCategory(t *testing.T) {
+	got := topProducts(t, evotor.TopProductsOptions{Category: "продукты"})
+	if got.Category != "Продукты" {
+		t.Errorf("category = %q, want Продукты", got.Category)
+	}
+	// Milk sits in the Молочные subgroup; the bag has no product_id and
+	// no category.
+	checkProducts(t, got, []wantProduct{
+		{"Молоко 3,2%", 3, 29000, 2},
+		{"Хлеб", 10, 15000, 1},
+	})
+	if got.Products[0].Category != "Продукты / Молочные" {
+		t.Errorf("milk category = %q, want the full path", got.Products[0].Category)
+	}
+
+	got = topProducts(t, evotor.TopProductsOptions{Category: "Продукты", GroupBy: "category"})
+	checkProducts(t, got, []wantProduct{
+		{"Молочные", 3, 29000, 2},
+		{"Продукты", 10, 15000, 1},
+	})
+}
+
+func TestTopProductsRejectsUnknownRanking(t *testing.T) {
+	client, _ := newStoreClient(t, nil, nil)
+	if _, err := client.GetTopProducts(context.Background(), time.Time{}, time.Time{}, nil, evotor.TopProductsOptions{By: "margin"}); err == nil {
+		t.Error("by=margin: want an error")
+	}
+}
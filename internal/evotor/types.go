@@ -1,18 +1,104 @@
 package evotor
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
 type Store struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 
+type Device struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	StoreID        string `json:"store_id"`
+	TimezoneOffset int64  `json:"timezone_offset,omitempty"`
+}
+
+type Employee struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	LastName       string   `json:"last_name,omitempty"`
+	PatronymicName string   `json:"patronymic_name,omitempty"`
+	Role           string   `json:"role,omitempty"`
+	Stores         []string `json:"stores,omitempty"`
+}
+
+func (e Employee) FullName() string {
+	return strings.Join(strings.Fields(strings.Join([]string{e.LastName, e.Name, e.PatronymicName}, " ")), " ")
+}
+
 type Item struct {
 	ID            string   `json:"id"`
 	Name          string   `json:"name"`
-	Price         float64  `json:"price,omitempty"`
+	Price         Money    `json:"price,omitempty"`
 	Code          string   `json:"code,omitempty"`
 	Barcodes      []string `json:"barcodes,omitempty"`
 	ArticleNumber string   `json:"article_number,omitempty"`
 	MeasureName   string   `json:"measure_name,omitempty"`
+	Quantity      float64  `json:"quantity"`
+	CostPrice     Money    `json:"cost_price,omitempty"`
+	ParentID      string   `json:"parent_id,omitempty"`
+	Group         bool     `json:"group,omitempty"`
+}
+
+// ItemMatch is an item found by search with the field that matched and a
+// relevance score in [0, 1]; exact code matches score 1.
+type ItemMatch struct {
+	Item
+	MatchedBy string  `json:"matched_by"`
+	Score     float64 `json:"score"`
+}
+
+type ProductGroup struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+type CategoryNode struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Items    int            `json:"items"`
+	Children []CategoryNode `json:"children,omitempty"`
+}
+
+type StockItem struct {
+	ProductID   string  `json:"product_id"`
+	Name        string  `json:"name"`
+	Category    string  `json:"category,omitempty"`
+	Quantity    float64 `json:"quantity"`
+	MeasureName string  `json:"measure_name,omitempty"`
+	Price       Money   `json:"price,omitempty"`
+	CostPrice   Money   `json:"cost_price,omitempty"`
+	CostValue   Money   `json:"cost_value"`
+}
+
+type StockReport struct {
+	StoreID     string      `json:"store_id,omitempty"`
+	Query       string      `json:"query,omitempty"`
+	Category    string      `json:"category,omitempty"`
+	Status      string      `json:"status"`
+	SyncedAt    time.Time   `json:"synced_at"`
+	Total       int         `json:"total_products"`
+	InStock     int         `json:"in_stock"`
+	Zero        int         `json:"zero"`
+	Negative    int         `json:"negative"`
+	CostValue   Money       `json:"cost_value"`
+	RetailValue Money       `json:"retail_value"`
+	Items       []StockItem `json:"items"`
+}
+
+type SyncResult struct {
+	StoreID  string    `json:"store_id"`
+	Full     bool      `json:"full"`
+	Fetched  int       `json:"fetched"`
+	Total    int       `json:"total"`
+	Groups   int       `json:"groups"`
+	SyncedAt time.Time `json:"synced_at"`
 }
 
 type DocumentPosition struct {
@@ -20,34 +106,100 @@ type DocumentPosition struct {
 	Name        string  `json:"name,omitempty"`
 	ProductName string  `json:"product_name,omitempty"`
 	Quantity    float64 `json:"quantity,omitempty"`
-	Price       float64 `json:"price,omitempty"`
-	Sum         float64 `json:"sum,omitempty"`
+	Price       Money   `json:"price,omitempty"`
+	Sum         Money   `json:"sum,omitempty"`
+	// PositionDiscount is set when a discount was applied to this position;
+	// ResultPrice and ResultSum are the values after it.
+	PositionDiscount *Discount `json:"position_discount,omitempty"`
+	ResultPrice      Money     `json:"result_price,omitempty"`
+	ResultSum        Money     `json:"result_sum,omitempty"`
+}
+
+type Discount struct {
+	Type    string  `json:"discount_type,omitempty"`
+	Sum     Money   `json:"discount_sum"`
+	Percent float64 `json:"discount_percent,omitempty"`
+}
+
+type DocumentPayment struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"`
+	Sum  Money  `json:"sum"`
 }
 
 type DocumentBody struct {
 	Positions []DocumentPosition `json:"positions,omitempty"`
-	Sum       float64            `json:"sum,omitempty"`
-	Total     float64            `json:"total,omitempty"`
+	Payments  []DocumentPayment  `json:"payments,omitempty"`
+	// DocDiscounts apply to the whole receipt on top of position discounts.
+	DocDiscounts []Discount `json:"doc_discounts,omitempty"`
+	Sum          Money      `json:"sum,omitempty"`
+	ResultSum    Money      `json:"result_sum,omitempty"`
+	Total        Money      `json:"total,omitempty"`
 }
 
 type DocumentShort struct {
-	ID        string       `json:"id"`
-	Type      string       `json:"type"`
-	CloseDate string       `json:"close_date"`
-	DeviceID  string       `json:"device_id"`
-	StoreID   string       `json:"store_id"`
-	Body      DocumentBody `json:"body"`
-	Total     float64      `json:"-"`
+	ID          string       `json:"id"`
+	Type        DocumentType `json:"type"`
+	CloseDate   string       `json:"close_date"`
+	DeviceID    string       `json:"device_id"`
+	StoreID     string       `json:"store_id"`
+	OpenUserID  string       `json:"open_user_id,omitempty"`
+	CloseUserID string       `json:"close_user_id,omitempty"`
+	Body        DocumentBody `json:"body"`
+	Total       Money        `json:"-"`
 }
 
 type DocumentFull struct {
-	ID        string       `json:"id"`
-	Type      string       `json:"type"`
-	CloseDate string       `json:"close_date"`
-	DeviceID  string       `json:"device_id"`
-	StoreID   string       `json:"store_id"`
-	Body      DocumentBody `json:"body"`
-	Total     float64      `json:"-"`
+	ID          string       `json:"id"`
+	Type        DocumentType `json:"type"`
+	CloseDate   string       `json:"close_date"`
+	DeviceID    string       `json:"device_id"`
+	StoreID     string       `json:"store_id"`
+	OpenUserID  string       `json:"open_user_id,omitempty"`
+	CloseUserID string       `json:"close_user_id,omitempty"`
+	Body        DocumentBody `json:"body"`
+	Total       Money        `json:"-"`
+	// Raw is the document exactly as Evotor sent it, including fields the
+	// typed view above does not model (taxes, marking codes, extras).
+	Raw json.RawMessage `json:"-"`
+}
+
+// EmployeeID is the user who closed the document, or who opened it when the
+// closing user is unknown.
+func (d DocumentFull) EmployeeID() string {
+	if d.CloseUserID != "" {
+		return d.CloseUserID
+	}
+	return d.OpenUserID
+}
+
+func (d DocumentFull) Short() DocumentShort {
+	return DocumentShort{
+		ID:          d.ID,
+		Type:        d.Type,
+		CloseDate:   d.CloseDate,
+		DeviceID:    d.DeviceID,
+		StoreID:     d.StoreID,
+		OpenUserID:  d.OpenUserID,
+		CloseUserID: d.CloseUserID,
+		Body:        d.Body,
+		Total:       d.Total,
+	}
+}
+
+// DocumentFields holds values of a document picked by path (see
+// DocumentFull.Field). Missing lists paths the document does not have.
+type DocumentFields struct {
+	ID      string         `json:"id"`
+	Fields  map[string]any `json:"fields"`
+	Missing []string       `json:"missing,omitempty"`
+}
+
+// DocumentPage is one page of SearchDocuments results. NextCursor is empty
+// on the last page.
+type DocumentPage struct {
+	Documents  []DocumentShort `json:"documents"`
+	NextCursor string          `json:"next_cursor,omitempty"`
 }
 
 type paging struct {
@@ -60,10 +212,198 @@ type listResponse[T any] struct {
 }
 
 type SalesMetrics struct {
-	Count         int            `json:"count"`
-	TotalSum      float64        `json:"total_sum"`
-	StoreID       string         `json:"store_id,omitempty"`
-	From          string         `json:"from"`
-	To            string         `json:"to"`
-	DocumentTypes map[string]int `json:"document_types,omitempty"`
+	Count         int                  `json:"count"`
+	TotalSum      Money                `json:"total_sum"`
+	StoreID       string               `json:"store_id,omitempty"`
+	From          string               `json:"from"`
+	To            string               `json:"to"`
+	DocumentTypes map[DocumentType]int `json:"document_types,omitempty"`
+	ReceiptStats
+}
+
+// ReceiptStats describes receipts (documents with positions) among the
+// counted documents: sales only, unless a single document type is requested.
+// Items are summed position quantities, positions are receipt lines.
+type ReceiptStats struct {
+	Receipts            int     `json:"receipts"`
+	AverageReceipt      Money   `json:"average_receipt"`
+	MedianReceipt       Money   `json:"median_receipt"`
+	P25Receipt          Money   `json:"p25_receipt"`
+	P75Receipt          Money   `json:"p75_receipt"`
+	P90Receipt          Money   `json:"p90_receipt"`
+	MinReceipt          Money   `json:"min_receipt"`
+	MaxReceipt          Money   `json:"max_receipt"`
+	ItemsPerReceipt     float64 `json:"items_per_receipt"`
+	PositionsPerReceipt float64 `json:"positions_per_receipt"`
+}
+
+// MetricChange compares one metric across two periods. Money metrics keep
+// kopeck precision in the delta.
+type MetricChange[T int | float64 | Money] struct {
+	Current  T        `json:"current"`
+	Previous T        `json:"previous"`
+	Delta    T        `json:"delta"`
+	Percent  *float64 `json:"percent_change"`
+}
+
+type SalesChanges struct {
+	Count           MetricChange[int]     `json:"count"`
+	TotalSum        MetricChange[Money]   `json:"total_sum"`
+	AverageReceipt  MetricChange[Money]   `json:"average_receipt"`
+	MedianReceipt   MetricChange[Money]   `json:"median_receipt"`
+	ItemsPerReceipt MetricChange[float64] `json:"items_per_receipt"`
+}
+
+type SalesComparison struct {
+	StoreID      string       `json:"store_id,omitempty"`
+	DocumentType DocumentType `json:"document_type"`
+	Baseline     string       `json:"baseline"`
+	Current      SalesMetrics `json:"current"`
+	Previous     SalesMetrics `json:"previous"`
+	Changes      SalesChanges `json:"changes"`
+}
+
+type StoreSalesMetrics struct {
+	StoreName string `json:"store_name,omitempty"`
+	SalesMetrics
+	Error string `json:"error,omitempty"`
+}
+
+type StoresSalesMetrics struct {
+	Stores []StoreSalesMetrics `json:"stores"`
+	Total  SalesMetrics        `json:"total"`
+}
+
+type SalesBucket struct {
+	Start          string `json:"start"`
+	Count          int    `json:"count"`
+	Revenue        Money  `json:"revenue"`
+	AverageReceipt Money  `json:"average_receipt"`
+}
+
+type SalesBreakdown struct {
+	StoreID        string        `json:"store_id,omitempty"`
+	From           string        `json:"from"`
+	To             string        `json:"to"`
+	Bucket         Bucket        `json:"bucket"`
+	Timezone       string        `json:"timezone"`
+	Category       string        `json:"category,omitempty"`
+	Count          int           `json:"count"`
+	Revenue        Money         `json:"revenue"`
+	AverageReceipt Money         `json:"average_receipt"`
+	Skipped        int           `json:"skipped,omitempty"`
+	Buckets        []SalesBucket `json:"buckets"`
+}
+
+type ProductStat struct {
+	ProductID  string  `json:"product_id,omitempty"`
+	CategoryID string  `json:"category_id,omitempty"`
+	Category   string  `json:"category,omitempty"`
+	Name       string  `json:"name"`
+	Quantity   float64 `json:"quantity"`
+	Revenue    Money   `json:"revenue"`
+	Receipts   int     `json:"receipts"`
+}
+
+type TopProducts struct {
+	StoreID      string        `json:"store_id,omitempty"`
+	From         string        `json:"from"`
+	To           string        `json:"to"`
+	By           string        `json:"by"`
+	GroupBy      string        `json:"group_by"`
+	Category     string        `json:"category,omitempty"`
+	DocumentType DocumentType  `json:"document_type"`
+	Total        int           `json:"total_products"`
+	Products     []ProductStat `json:"products"`
+}
+
+type MetricsAmount struct {
+	Count int   `json:"count"`
+	Sum   Money `json:"sum"`
+}
+
+type NetSalesMetrics struct {
+	StoreID       string               `json:"store_id,omitempty"`
+	From          string               `json:"from"`
+	To            string               `json:"to"`
+	GrossSales    MetricsAmount        `json:"gross_sales"`
+	Returns       MetricsAmount        `json:"returns"`
+	NetRevenue    Money                `json:"net_revenue"`
+	DocumentTypes map[DocumentType]int `json:"document_types,omitempty"`
+}
+
+type PaymentTypeRow struct {
+	Type  string  `json:"type"`
+	Count int     `json:"count"`
+	Sum   Money   `json:"sum"`
+	Share float64 `json:"share_percent"`
+}
+
+type PaymentMetrics struct {
+	StoreID      string           `json:"store_id,omitempty"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	DocumentType DocumentType     `json:"document_type"`
+	Count        int              `json:"count"`
+	Revenue      Money            `json:"revenue"`
+	Payments     []PaymentTypeRow `json:"payments"`
+}
+
+type ProductDiscount struct {
+	ProductID   string  `json:"product_id,omitempty"`
+	Name        string  `json:"name"`
+	Quantity    float64 `json:"quantity"`
+	GrossSum    Money   `json:"gross_sum"`
+	DiscountSum Money   `json:"discount_sum"`
+	DiscountPct float64 `json:"discount_percent"`
+	Receipts    int     `json:"receipts"`
+}
+
+type DiscountReport struct {
+	StoreID             string            `json:"store_id,omitempty"`
+	From                string            `json:"from"`
+	To                  string            `json:"to"`
+	Receipts            int               `json:"receipts"`
+	DiscountedReceipts  int               `json:"discounted_receipts"`
+	DiscountedShare     float64           `json:"discounted_share_percent"`
+	GrossSum            Money             `json:"gross_sum"`
+	DiscountSum         Money             `json:"discount_sum"`
+	PositionDiscountSum Money             `json:"position_discount_sum"`
+	DocumentDiscountSum Money             `json:"document_discount_sum"`
+	NetSum              Money             `json:"net_sum"`
+	DiscountRate        float64           `json:"discount_rate_percent"`
+	TotalProducts       int               `json:"total_products"`
+	Products            []ProductDiscount `json:"products"`
+}
+
+type DeviceSalesRow struct {
+	DeviceID       string `json:"device_id"`
+	DeviceName     string `json:"device_name,omitempty"`
+	Count          int    `json:"count"`
+	Revenue        Money  `json:"revenue"`
+	AverageReceipt Money  `json:"average_receipt"`
+}
+
+type DeviceSales struct {
+	StoreID      string           `json:"store_id,omitempty"`
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	DocumentType DocumentType     `json:"document_type"`
+	Devices      []DeviceSalesRow `json:"devices"`
+}
+
+type EmployeeSalesRow struct {
+	EmployeeID     string        `json:"employee_id"`
+	EmployeeName   string        `json:"employee_name,omitempty"`
+	Sales          MetricsAmount `json:"sales"`
+	Returns        MetricsAmount `json:"returns"`
+	NetRevenue     Money         `json:"net_revenue"`
+	AverageReceipt Money         `json:"average_receipt"`
+}
+
+type EmployeeSales struct {
+	StoreID   string             `json:"store_id,omitempty"`
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	Employees []EmployeeSalesRow `json:"employees"`
 }
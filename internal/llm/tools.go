@@ -2,11 +2,34 @@ package llm
 
 import openrouter "github.com/revrost/go-openrouter"
 
+// documentTypeEnum lists Evotor document types accepted by document_type
+// filters, plus ALL.
+var documentTypeEnum = []string{
+	"SELL", "PAYBACK", "BUY", "BUYBACK",
+	"CASH_INCOME", "CASH_OUTCOME", "OPEN_SESSION", "CLOSE_SESSION",
+	"ACCEPT", "RETURN", "WRITE_OFF", "INVENTORY", "REVALUATION", "OPEN_TARE",
+	"ALL",
+}
+
+const documentTypesHelp = "SELL is a sale, PAYBACK a customer return, BUY/BUYBACK a purchase from a customer and its return, CASH_INCOME/CASH_OUTCOME cash put into or taken from the drawer, OPEN_SESSION/CLOSE_SESSION a shift, ACCEPT, RETURN (to the supplier), WRITE_OFF, INVENTORY, REVALUATION and OPEN_TARE are stock documents."
+
 func ToolSchemas() []openrouter.Tool {
 	return []openrouter.Tool{
 		getSalesMetricsTool(),
+		getSalesMetricsByStoresTool(),
+		compareSalesMetricsTool(),
+		getSalesBreakdownTool(),
+		getTopProductsTool(),
+		getDiscountReportTool(),
+		getSalesByDeviceTool(),
+		listDevicesTool(),
+		getSalesByEmployeeTool(),
+		listEmployeesTool(),
 		listStoresTool(),
+		getCategoryTreeTool(),
+		getStockTool(),
 		searchItemsTool(),
+		lookupItemsTool(),
 		searchDocumentsTool(),
 		getDocumentTool(),
 	}
@@ -17,23 +40,345 @@ func getSalesMetricsTool() openrouter.Tool {
 		Type: openrouter.ToolTypeFunction,
 		Function: &openrouter.FunctionDefinition{
 			Name:        "GetSalesMetrics",
-			Description: "Get sales count and total sum for a period. Returns count, total_sum, store_id, period (from/to), and document_types with counts. Use this for 'how many receipts' or 'sum for period' queries. Much faster than SearchDocuments + aggregation. Default: counts only SELL documents (sales).",
+			Description: "Get sales count and total sum for a period. Returns count, total_sum (after discounts), store_id, period (from/to), document_types with counts, and receipt stats: receipts, average_receipt, median_receipt, p25/p75/p90_receipt, min/max_receipt, items_per_receipt, positions_per_receipt. Use this for 'how many receipts', 'sum for period', 'средний/медианный чек' or 'товаров в чеке' queries. Much faster than SearchDocuments + aggregation. Default: counts only SELL documents (sales).",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"from": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Start date in the store's local time (e.g., 2025-01-01T00:00:00). If not specified, use 7 days ago.",
+					},
+					"to": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "End date in the store's local time (e.g., 2025-01-31T23:59:59). If not specified, use now.",
+					},
+					"document_type": map[string]any{
+						"type":        "string",
+						"enum":        documentTypeEnum,
+						"description": "Document type to count: SELL for sales only (also when omitted), ALL for every type. " + documentTypesHelp,
+					},
+					"net": map[string]any{
+						"type":        "boolean",
+						"description": "If true, return gross_sales (SELL), customer returns (PAYBACK) with count and sum each, and net_revenue = gross - returns, instead of a single count/total_sum. document_type is ignored. Use for 'выручка с учётом возвратов' or 'чистая выручка'.",
+					},
+					"by_payment": map[string]any{
+						"type":        "boolean",
+						"description": "If true, return revenue split by payment type (CASH, CARD, ELECTRON, ...) with count, sum and share_percent per type, plus total count and revenue. A receipt paid by several types counts towards each. document_type applies (default SELL); with ALL, PAYBACK sums are subtracted. Use for 'наличные/безнал', 'оплата картой'.",
+					},
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"required":             []string{"from", "to"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func getSalesMetricsByStoresTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "GetSalesMetricsByStores",
+			Description: "Get sales count and total sum for a period across several stores at once. Returns stores (per-store rows with store_id, store_name, count, total_sum, document_types, receipt stats as in GetSalesMetrics, error if that store failed) and total (grand total over stores without errors). Use for chain-wide questions or comparing stores. The period applies in each store's own timezone. Default: all stores from ListStores, SELL documents only.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"from": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Start date in the store's local time (e.g., 2025-01-01T00:00:00). If not specified, use 7 days ago.",
+					},
+					"to": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "End date in the store's local time (e.g., 2025-01-31T23:59:59). If not specified, use now.",
+					},
+					"document_type": map[string]any{
+						"type":        "string",
+						"enum":        documentTypeEnum,
+						"description": "Document type to count: SELL for sales only (also when omitted), ALL for every type. " + documentTypesHelp,
+					},
+					"store_ids": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Optional subset of store IDs. Omit to use all stores.",
+					},
+				},
+				"required":             []string{"from", "to"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func compareSalesMetricsTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "CompareSalesMetrics",
+			Description: "Compare sales of a period with a baseline period. Returns current and previous metric sets (as in GetSalesMetrics) and changes keyed by count, total_sum, average_receipt, median_receipt and items_per_receipt, each with current, previous, delta and percent_change (null when the baseline is zero). Give either compare_from/compare_to or baseline. Use for 'сравни с прошлой неделей', 'к прошлому году'.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"from": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Start of the current period in the store's local time (e.g., 2025-01-01T00:00:00).",
+					},
+					"to": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "End of the current period in the store's local time (e.g., 2025-01-31T23:59:59).",
+					},
+					"baseline": map[string]any{
+						"type":        "string",
+						"enum":        []string{"previous", "previous_month", "last_year"},
+						"description": "How to pick the baseline when compare_from/compare_to are omitted: previous (same length right before, default), previous_month (same dates a month earlier), last_year (same dates a year earlier).",
+					},
+					"compare_from": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Optional explicit start of the baseline period in the store's local time.",
+					},
+					"compare_to": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Optional explicit end of the baseline period in the store's local time.",
+					},
+					"document_type": map[string]any{
+						"type":        "string",
+						"enum":        documentTypeEnum,
+						"description": "Document type to compare: SELL for sales only (also when omitted), ALL for every type. " + documentTypesHelp,
+					},
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"required":             []string{"from", "to"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func getSalesBreakdownTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "GetSalesBreakdown",
+			Description: "Get sales grouped by time bucket (hour, day, week starting Monday, or month) for a period and store. Returns buckets with start, count, revenue and average_receipt (including empty buckets), plus period totals. Use for 'sales by day/hour/week/month' or trend questions instead of summing documents yourself. Default: SELL documents, day buckets.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"from": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Start date in the store's local time (e.g., 2025-12-01T00:00:00).",
+					},
+					"to": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "End date in the store's local time (e.g., 2025-12-31T23:59:59).",
+					},
+					"bucket": map[string]any{
+						"type":        "string",
+						"enum":        []string{"hour", "day", "week", "month"},
+						"description": "Bucket size (default: day).",
+					},
+					"timezone": map[string]any{
+						"type":        "string",
+						"description": "Optional IANA timezone for bucket boundaries (e.g., Asia/Vladivostok). Default: the store's timezone.",
+					},
+					"category": map[string]any{
+						"type":        "string",
+						"description": "Optional product group (category) name or ID; counts only positions of this group and its subgroups.",
+					},
+					"document_type": map[string]any{
+						"type":        "string",
+						"enum":        documentTypeEnum,
+						"description": "Document type to count: SELL for sales only (also when omitted), ALL for every type. " + documentTypesHelp,
+					},
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"required":             []string{"from", "to"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func getTopProductsTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "GetTopProducts",
+			Description: "Rank products sold in a period by revenue or quantity, aggregated from document positions. Returns products with product_id, name, quantity, revenue, receipts (number of documents containing the product) and total_products. Products are grouped by product_id, or by name when the ID is missing. Default: SELL documents, by revenue, limit 10.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"from": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Start date in the store's local time (e.g., 2025-01-01T00:00:00). If not specified, use 7 days ago.",
+					},
+					"to": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "End date in the store's local time (e.g., 2025-01-31T23:59:59). If not specified, use now.",
+					},
+					"by": map[string]any{
+						"type":        "string",
+						"enum":        []string{"revenue", "quantity"},
+						"description": "Ranking metric (default: revenue).",
+					},
+					"category": map[string]any{
+						"type":        "string",
+						"description": "Optional product group (category) name or ID; includes its subgroups. Use GetCategoryTree to see available groups.",
+					},
+					"group_by": map[string]any{
+						"type":        "string",
+						"enum":        []string{"product", "category"},
+						"description": "Aggregate by product (default) or by category: top-level groups, or direct subgroups of category when it is set.",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of products to return (default: 10, max: 50).",
+					},
+					"document_type": map[string]any{
+						"type":        "string",
+						"enum":        documentTypeEnum,
+						"description": "Document type to aggregate: SELL for sales only (also when omitted), ALL for every type. " + documentTypesHelp,
+					},
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"required":             []string{"from", "to"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func getDiscountReportTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "GetDiscountReport",
+			Description: "Summarize discounts on sales (SELL) for a period. Returns receipts, discounted_receipts, discounted_share_percent, gross_sum (before discounts), discount_sum (markups count as negative discounts, so gross_sum - discount_sum = net_sum), position_discount_sum, document_discount_sum, net_sum, discount_rate_percent, and products ranked by discount with quantity, gross_sum, discount_sum, discount_percent, receipts.",
 			Parameters: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
 					"from": map[string]any{
 						"type":        "string",
 						"format":      "date-time",
-						"description": "Start date in RFC3339 format (e.g., 2025-01-01T00:00:00Z). If not specified, use 7 days ago.",
+						"description": "Start date in the store's local time (e.g., 2025-01-01T00:00:00). If not specified, use 7 days ago.",
 					},
 					"to": map[string]any{
 						"type":        "string",
 						"format":      "date-time",
-						"description": "End date in RFC3339 format (e.g., 2025-01-31T23:59:59Z). If not specified, use now.",
+						"description": "End date in the store's local time (e.g., 2025-01-31T23:59:59). If not specified, use now.",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of products to return (default: 10, max: 50).",
+					},
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"required":             []string{"from", "to"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func getSalesByDeviceTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "GetSalesByDevice",
+			Description: "Break down sales for a period by cash register (device). Returns devices sorted by revenue with device_id, device_name, count, revenue and average_receipt; registers without sales are listed with zeros. Use for 'which register sold the most'. Default: SELL documents.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"from": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Start date in the store's local time (e.g., 2025-01-01T00:00:00). If not specified, use 7 days ago.",
+					},
+					"to": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "End date in the store's local time (e.g., 2025-01-31T23:59:59). If not specified, use now.",
 					},
 					"document_type": map[string]any{
 						"type":        "string",
-						"description": "Document type to count. Use 'SELL' for sales only (default). Use 'ALL' to include all types (SELL, RETURN, REFUND).",
+						"enum":        documentTypeEnum,
+						"description": "Document type to count: SELL for sales only (also when omitted), ALL for every type. " + documentTypesHelp,
+					},
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"required":             []string{"from", "to"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func listDevicesTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "ListDevices",
+			Description: "List cash registers (devices) of a store. Returns devices with id, name, store_id and timezone_offset (milliseconds).",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func getSalesByEmployeeTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "GetSalesByEmployee",
+			Description: "Break down sales and returns for a period by employee (cashier who closed the document). Returns employees sorted by net_revenue with employee_id, employee_name, sales {count, sum}, returns {count, sum}, net_revenue and average_receipt; documents without a cashier are grouped under an empty employee_id. Use for 'which cashier sold the most'.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"from": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "Start date in the store's local time (e.g., 2025-01-01T00:00:00). If not specified, use 7 days ago.",
+					},
+					"to": map[string]any{
+						"type":        "string",
+						"format":      "date-time",
+						"description": "End date in the store's local time (e.g., 2025-01-31T23:59:59). If not specified, use now.",
 					},
 					"store_id": map[string]any{
 						"type":        "string",
@@ -47,6 +392,26 @@ func getSalesMetricsTool() openrouter.Tool {
 	}
 }
 
+func listEmployeesTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "ListEmployees",
+			Description: "List employees of a store. Returns employees with id, name, last_name, patronymic_name, role and stores.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
 func listStoresTool() openrouter.Tool {
 	return openrouter.Tool{
 		Type: openrouter.ToolTypeFunction,
@@ -62,18 +427,79 @@ func listStoresTool() openrouter.Tool {
 	}
 }
 
+func getCategoryTreeTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "GetCategoryTree",
+			Description: "Get the product group (category) tree of a store. Returns nodes with id, name, items (number of products including subgroups) and children.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func getStockTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "GetStock",
+			Description: "Get current stock balances (остатки) for a product and/or category. Returns total_products, in_stock, zero and negative counts, cost_value and retail_value of positive stock, and items with product_id, name, category, quantity, measure_name, price, cost_price, cost_value. Items with zero or negative stock are included and listed first.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Optional text to search for in product names (fuzzy: word forms, abbreviations and small typos are tolerated).",
+					},
+					"category": map[string]any{
+						"type":        "string",
+						"description": "Optional product group (category) name or ID; includes its subgroups.",
+					},
+					"status": map[string]any{
+						"type":        "string",
+						"enum":        []string{"all", "in_stock", "out_of_stock", "negative"},
+						"description": "Filter by balance (default: all). out_of_stock means zero or negative.",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of items to return (default: 10, max: 50). Totals cover all matched products.",
+					},
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
 func searchItemsTool() openrouter.Tool {
 	return openrouter.Tool{
 		Type: openrouter.ToolTypeFunction,
 		Function: &openrouter.FunctionDefinition{
 			Name:        "SearchItems",
-			Description: "Find items by free-text query. Returns items with id, name, price, code, barcodes, article_number, measure_name. Search is case-insensitive and matches substrings in item names. Default limit: 10.",
+			Description: "Find items by free-text query. Returns items with id, name, price, cost_price, quantity, code, barcodes, article_number, measure_name, parent_id, matched_by and score (0..1, best first). Name search is fuzzy: it understands word forms, ё/е, abbreviations like «д/волос» or «жев. рез.» and small typos; exact barcode, article number or code matches come first. Use category to list or search items of a product group. Default limit: 10.",
 			Parameters: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
 					"query": map[string]any{
 						"type":        "string",
-						"description": "Text to search for in item names (case-insensitive substring match).",
+						"description": "Item name, words of it or a barcode/article/code. May be empty when category is set.",
+					},
+					"category": map[string]any{
+						"type":        "string",
+						"description": "Optional product group (category) name or ID; includes its subgroups. Use GetCategoryTree to see available groups.",
 					},
 					"limit": map[string]any{
 						"type":        "integer",
@@ -84,7 +510,31 @@ func searchItemsTool() openrouter.Tool {
 						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
 					},
 				},
-				"required":             []string{"query"},
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func lookupItemsTool() openrouter.Tool {
+	return openrouter.Tool{
+		Type: openrouter.ToolTypeFunction,
+		Function: &openrouter.FunctionDefinition{
+			Name:        "LookupItems",
+			Description: "Find items by exact barcode, article number or internal code. Returns items as in SearchItems plus matched_by (barcode, article_number or code). Use when the user gives a barcode (EAN-13/EAN-8 digits), артикул or код товара.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"code": map[string]any{
+						"type":        "string",
+						"description": "Barcode, article number or item code to match exactly.",
+					},
+					"store_id": map[string]any{
+						"type":        "string",
+						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
+					},
+				},
+				"required":             []string{"code"},
 				"additionalProperties": false,
 			},
 		},
@@ -96,31 +546,31 @@ func searchDocumentsTool() openrouter.Tool {
 		Type: openrouter.ToolTypeFunction,
 		Function: &openrouter.FunctionDefinition{
 			Name:        "SearchDocuments",
-			Description: "List documents for a period and store. Returns documents with id, timestamp, total, type, store_id, device_id. Types include SELL (sale), RETURN (return), REFUND (refund). Use item_query to filter documents that contain a specific item name in positions (this will fetch full documents and check positions locally). Default limit: 50.",
+			Description: "List documents for a period and store. Returns documents (id, timestamp, total, type, store_id, device_id) and next_cursor when more documents remain. Types: " + documentTypesHelp + " Use item_query to filter documents that contain a specific item name in positions (this will fetch full documents and check positions locally). Default limit: 50.",
 			Parameters: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
 					"from": map[string]any{
 						"type":        "string",
 						"format":      "date-time",
-						"description": "Start date in RFC3339 format (e.g., 2025-01-01T00:00:00Z). If not specified, use 7 days ago.",
+						"description": "Start date in the store's local time (e.g., 2025-01-01T00:00:00). If not specified, use 7 days ago.",
 					},
 					"to": map[string]any{
 						"type":        "string",
 						"format":      "date-time",
-						"description": "End date in RFC3339 format (e.g., 2025-01-31T23:59:59Z). If not specified, use now.",
+						"description": "End date in the store's local time (e.g., 2025-01-31T23:59:59). If not specified, use now.",
 					},
 					"limit": map[string]any{
 						"type":        "integer",
 						"description": "Maximum number of documents to return (default: 50, max: 200).",
 					},
-					"offset": map[string]any{
-						"type":        "integer",
-						"description": "Number of documents to skip (for pagination).",
+					"cursor": map[string]any{
+						"type":        "string",
+						"description": "next_cursor from a previous SearchDocuments result to get the next page ('покажи следующие'). The cursor keeps the original period and store, so from, to and store_id are ignored with it.",
 					},
 					"item_query": map[string]any{
 						"type":        "string",
-						"description": "Optional text to search for in document positions. If provided, fetches full documents and filters locally to find items matching this query (case-insensitive).",
+						"description": "Optional text to search for in document positions. If provided, fetches full documents and filters locally to find items matching this query (fuzzy, tolerates word forms and small typos).",
 					},
 					"store_id": map[string]any{
 						"type":        "string",
@@ -139,7 +589,7 @@ func getDocumentTool() openrouter.Tool {
 		Type: openrouter.ToolTypeFunction,
 		Function: &openrouter.FunctionDefinition{
 			Name:        "GetDocument",
-			Description: "Fetch a single document with all positions. Returns document id, type (SELL/RETURN/REFUND), close_date, total, store_id, device_id, and positions with product_id, name, quantity, price, sum. Use for detailed inspection of specific documents.",
+			Description: "Fetch a single document with all positions. Returns document id, type (SELL, PAYBACK, CASH_INCOME, ...), close_date, total, store_id, device_id, and positions with product_id, name, quantity, price, sum. Use for detailed inspection of specific documents. Fields not listed here (taxes, marking codes, customer contacts in extras, ...) are available with raw or fields.",
 			Parameters: map[string]any{
 				"type": "object",
 				"properties": map[string]any{
@@ -147,6 +597,15 @@ func getDocumentTool() openrouter.Tool {
 						"type":        "string",
 						"description": "Document ID to fetch.",
 					},
+					"fields": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Optional paths into the original Evotor document; returns only their values (fields) and the paths not found (missing). Dots separate keys, numbers index arrays, * takes every element: body.positions.*.tax, body.positions.0.mark, extras.customer_phone.",
+					},
+					"raw": map[string]any{
+						"type":        "boolean",
+						"description": "If true, return the document exactly as Evotor sent it, with every field. Prefer fields when you know what to look for: raw documents are large.",
+					},
 					"store_id": map[string]any{
 						"type":        "string",
 						"description": "Optional store ID. Use when the user selected a specific store; otherwise omit to use the default store.",
@@ -0,0 +1,122 @@
+// Package match scores how well a product name matches a free-text query
+// written the way people type in Russian retail: inflected words, ё/е,
+// latin letters in place of cyrillic ones, shorthand like "д/волос" or
+// "жев. рез." and small typos.
+package match
+
+// Match qualities of a single query word, best first.
+const (
+	qualityExact  = 1.0
+	qualityStem   = 0.95
+	qualityAbbrev = 0.9
+	qualityPrefix = 0.8
+	qualityTypo1  = 0.7
+	qualityTypo2  = 0.55
+)
+
+// minTypoLen is the shortest query word that tolerates a typo.
+const minTypoLen = 5
+
+// Matcher is a compiled query. The zero value and queries made only of
+// stopwords match everything.
+type Matcher struct {
+	tokens []token
+}
+
+func New(query string) *Matcher {
+	return &Matcher{tokens: tokenize(query)}
+}
+
+// Empty reports whether the query has no meaningful words.
+func (m *Matcher) Empty() bool {
+	return m == nil || len(m.tokens) == 0
+}
+
+// Score rates text against the query in [0, 1]; 0 means no match. Every
+// query word has to be found in text. The score is the average quality of
+// word matches, slightly lowered when text has many words the query did not
+// mention, so "Лак для волос" ranks above "Лак для волос с экстрактом ромашки".
+func (m *Matcher) Score(text string) float64 {
+	if m.Empty() {
+		return 1
+	}
+	words := tokenize(text)
+	if len(words) == 0 {
+		return 0
+	}
+
+	used := make([]bool, len(words))
+	var total float64
+	for _, q := range m.tokens {
+		best, bestAt := 0.0, -1
+		for i, w := range words {
+			if quality := wordQuality(q, w); quality > best {
+				best, bestAt = quality, i
+			}
+		}
+		if bestAt < 0 {
+			return 0
+		}
+		used[bestAt] = true
+		total += best
+	}
+
+	covered := 0
+	for _, u := range used {
+		if u {
+			covered++
+		}
+	}
+	coverage := float64(covered) / float64(len(words))
+	return total / float64(len(m.tokens)) * (0.8 + 0.2*coverage)
+}
+
+// Matches reports whether text matches the query at all.
+func (m *Matcher) Matches(text string) bool {
+	return m.Score(text) > 0
+}
+
+func wordQuality(q, w token) float64 {
+	if q.numeric || w.numeric {
+		if q.numeric && w.numeric && q.text == w.text {
+			return qualityExact
+		}
+		return 0
+	}
+	switch {
+	case q.text == w.text:
+		return qualityExact
+	case q.stem == w.stem:
+		return qualityStem
+	case q.abbrev && hasPrefix(w.text, q.text):
+		return qualityAbbrev
+	case len([]rune(q.stem)) >= minStemLen && hasPrefix(w.text, q.stem):
+		return qualityPrefix
+	}
+
+	// Typos are judged on whole words: short stems differ by one letter too
+	// easily ("молок" and "молот").
+	n := len([]rune(q.text))
+	if n < minTypoLen {
+		return 0
+	}
+	allowed := 1
+	if n >= 8 {
+		allowed = 2
+	}
+	d := distance(q.text, w.text)
+	if len([]rune(q.stem)) >= 6 {
+		d = min(d, distance(q.stem, w.stem))
+	}
+	switch {
+	case d == 1:
+		return qualityTypo1
+	case d <= allowed:
+		return qualityTypo2
+	}
+	return 0
+}
+
+func hasPrefix(word, prefix string) bool {
+	return len(word) >= len(prefix) && word[:len(prefix)] == prefix
+}
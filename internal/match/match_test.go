@@ -0,0 +1,61 @@
+package match
+
+import "testing"
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		query, text string
+		want        bool
+	}{
+		{"лак для волос", "Лак для волос сильной фиксации", true},
+		{"лаки", "Лак для волос", true},
+		{"мёд", "Мед натуральный 250 г", true},
+		{"мoлoкo", "Молоко 3,2%", true},
+		{"жев. рез.", "Жевательная резинка мятная", true},
+		{"д/волос", "Шампунь для волос", true},
+		{"шапмунь", "Шампунь детский", true},
+		{"кола 0,5", "Кола 0.5 л", true},
+		{"кола 0,5", "Кола 1 л", false},
+		{"сир", "Сыр российский", false},
+		{"молоко", "Кефир 1%", false},
+		{"лак волос", "Лак для ногтей", false},
+		{"shampoo", "Shampoo Clear", true},
+	}
+	for _, tt := range tests {
+		if got := New(tt.query).Matches(tt.text); got != tt.want {
+			t.Errorf("New(%q).Matches(%q) = %v, want %v", tt.query, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestScore(t *testing.T) {
+	m := New("лак для волос")
+	if got := m.Score("Лак для волос"); got != 1 {
+		t.Errorf("exact name: score = %v, want 1", got)
+	}
+	if exact, longer := m.Score("Лак для волос"), m.Score("Лак для волос с экстрактом ромашки"); exact <= longer {
+		t.Errorf("exact name scored %v, longer name %v; want the exact name first", exact, longer)
+	}
+	if exact, stemmed := m.Score("Лак для волос"), m.Score("Лаки для волос"); exact <= stemmed {
+		t.Errorf("exact word scored %v, inflected %v; want the exact word first", exact, stemmed)
+	}
+	if stemmed, typo := m.Score("Лаки для волос"), New("лак для волсо").Score("Лаки для волос"); stemmed <= typo {
+		t.Errorf("inflected word scored %v, typo %v; want the inflected word first", stemmed, typo)
+	}
+	if got := m.Score(""); got != 0 {
+		t.Errorf("empty text: score = %v, want 0", got)
+	}
+}
+
+func TestEmptyQueryMatchesEverything(t *testing.T) {
+	for _, query := range []string{"", "  ", "для и с", "шт."} {
+		m := New(query)
+		if !m.Empty() || m.Score("Молоко") != 1 {
+			t.Errorf("New(%q): Empty = %v, score = %v, want an empty query matching everything", query, m.Empty(), m.Score("Молоко"))
+		}
+	}
+	var m *Matcher
+	if !m.Empty() || !m.Matches("Молоко") {
+		t.Error("nil Matcher: want an empty query matching everything")
+	}
+}
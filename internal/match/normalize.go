@@ -0,0 +1,117 @@
+package match
+
+import (
+	"strings"
+	"unicode"
+)
+
+// token is a normalized word of a query or product name.
+type token struct {
+	text string
+	stem string
+	// abbrev marks query words written with a trailing dot ("жев."), which
+	// may match any word starting with them.
+	abbrev  bool
+	numeric bool
+}
+
+// stopwords carry no meaning for product matching; single letters left over
+// from shorthand like "д/волос" are dropped as well.
+var stopwords = map[string]bool{
+	"для": true, "и": true, "в": true, "во": true, "с": true, "со": true,
+	"на": true, "без": true, "по": true, "из": true, "от": true, "к": true,
+	"у": true, "о": true,
+	"шт": true, "мл": true, "л": true, "г": true, "гр": true, "кг": true,
+	"уп": true, "упак": true,
+}
+
+// lookalikes maps latin letters that look like cyrillic ones. They are
+// replaced only inside words that already contain cyrillic, so genuine
+// latin brand names stay intact.
+var lookalikes = map[rune]rune{
+	'a': 'а', 'b': 'в', 'c': 'с', 'e': 'е', 'h': 'н', 'k': 'к', 'm': 'м',
+	'o': 'о', 'p': 'р', 't': 'т', 'x': 'х', 'y': 'у',
+}
+
+func tokenize(text string) []token {
+	runes := []rune(strings.ToLower(text))
+	var tokens []token
+	var word []rune
+	flush := func(abbrev bool) {
+		if len(word) == 0 {
+			return
+		}
+		if t, ok := newToken(string(word), abbrev); ok {
+			tokens = append(tokens, t)
+		}
+		word = word[:0]
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == 'ё':
+			word = append(word, 'е')
+		case unicode.IsLetter(r):
+			if len(word) > 0 && unicode.IsDigit(word[len(word)-1]) {
+				flush(false)
+			}
+			word = append(word, r)
+		case unicode.IsDigit(r):
+			if len(word) > 0 && !isNumber(word) {
+				flush(false)
+			}
+			word = append(word, r)
+		case (r == '.' || r == ',') && isNumber(word) && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			word = append(word, '.')
+		default:
+			flush(r == '.')
+		}
+	}
+	flush(false)
+	return tokens
+}
+
+func newToken(word string, abbrev bool) (token, bool) {
+	word = fixLookalikes(word)
+	if stopwords[word] {
+		return token{}, false
+	}
+	if isNumber([]rune(word)) {
+		return token{text: word, stem: word, numeric: true}, true
+	}
+	if len([]rune(word)) < 2 {
+		return token{}, false
+	}
+	return token{text: word, stem: stem(word), abbrev: abbrev}, true
+}
+
+func fixLookalikes(word string) string {
+	hasCyrillic := false
+	for _, r := range word {
+		if unicode.Is(unicode.Cyrillic, r) {
+			hasCyrillic = true
+			break
+		}
+	}
+	if !hasCyrillic {
+		return word
+	}
+	return strings.Map(func(r rune) rune {
+		if c, ok := lookalikes[r]; ok {
+			return c
+		}
+		return r
+	}, word)
+}
+
+func isNumber(word []rune) bool {
+	if len(word) == 0 {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsDigit(r) && r != '.' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package match
+
+import (
+	"strings"
+	"unicode"
+)
+
+// minStemLen keeps short words from being cut down to meaningless roots.
+const minStemLen = 3
+
+// endings are Russian inflection endings, longest first. Stripping one of
+// them is a light stemmer: it maps "лаки", "лаком" and "лак" to one stem
+// without pulling in a full morphology dictionary.
+var endings = []string{
+	"иями", "ями", "ами", "ого", "его", "ому", "ему", "ыми", "ими", "ией",
+	"ий", "ый", "ой", "ая", "яя", "ое", "ее", "ые", "ие", "ую", "юю",
+	"ов", "ев", "ей", "ам", "ям", "ах", "ях", "ом", "ем", "ью",
+	"а", "я", "о", "е", "ы", "и", "у", "ю", "ь", "й",
+}
+
+func stem(word string) string {
+	if !isCyrillicWord(word) {
+		return word
+	}
+	for _, ending := range endings {
+		if !strings.HasSuffix(word, ending) {
+			continue
+		}
+		if base := strings.TrimSuffix(word, ending); len([]rune(base)) >= minStemLen {
+			return base
+		}
+	}
+	return word
+}
+
+func isCyrillicWord(word string) bool {
+	for _, r := range word {
+		if !unicode.Is(unicode.Cyrillic, r) {
+			return false
+		}
+	}
+	return word != ""
+}
+
+// distance is the optimal string alignment distance: Levenshtein plus
+// transposition of adjacent letters, the most common typing slip.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(rb)]
+}
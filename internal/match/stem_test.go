@@ -0,0 +1,43 @@
+package match
+
+import "testing"
+
+func TestStem(t *testing.T) {
+	tests := map[string]string{
+		"лак":       "лак",
+		"лаки":      "лак",
+		"лаком":     "лак",
+		"молоко":    "молок",
+		"детского":  "детск",
+		"ватными":   "ватн",
+		"сыр":       "сыр",
+		"чая":       "чая",
+		"shampoo":   "shampoo",
+		"кола0":     "кола0",
+		"резинками": "резинк",
+	}
+	for word, want := range tests {
+		if got := stem(word); got != want {
+			t.Errorf("stem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"молоко", "молоко", 0},
+		{"молоко", "моолко", 1},
+		{"молоко", "молок", 1},
+		{"шампунь", "шампуть", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}